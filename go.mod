@@ -15,6 +15,7 @@ require (
 	github.com/lxzan/event_emitter v0.2.0
 	github.com/lxzan/gws v1.8.3
 	github.com/markbates/goth v1.79.0
+	github.com/redis/go-redis/v9 v9.5.1
 	github.com/surrealdb/surrealdb.go v0.2.1
 )
 
@@ -23,8 +24,10 @@ require (
 	cloud.google.com/go/compute/metadata v0.2.3 // indirect
 	github.com/andybalholm/brotli v1.1.0 // indirect
 	github.com/bep/debounce v1.2.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/dolthub/maphash v0.1.0 // indirect
 	github.com/gammazero/deque v0.2.1 // indirect
 	github.com/go-jose/go-jose/v3 v3.0.3 // indirect
@@ -8,6 +8,7 @@ import (
 	"os"
 	"slices"
 	"strings"
+	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	_ "github.com/joho/godotenv/autoload"
@@ -21,33 +22,80 @@ type Service interface {
 	GetUser(id, username, email string) (models.User, error)
 	GetSession(id string) (models.Session, error)
 	DeleteSession(id string) error
+	CreateWebsocketTicket(sessionId, userId string) (string, error)
+	ConsumeWebsocketTicket(ticket string) (models.WebsocketTicket, error)
 	GetFriends(userId string) ([]models.User, error)
 	GetUsersFromChannel(channelId string) ([]string, error)
 	GetUserServers(userId string) ([]models.Server, error)
+	GetUserServersWithChannels(userId string) ([]models.Server, error)
 	GetServer(userId, serverId string) (models.Server, error)
-	GetPrivateMessages(userId, channelId string) ([]models.Message, error)
-	GetChannelMessages(channelId string, limit, before int) ([]models.Message, error)
+	GetPrivateMessages(userId, channelId string, page models.MessagePage) ([]models.Message, bool, error)
+	EnsureDMChannel(userId, otherId string) (string, bool, error)
+	TouchDMChannel(dmId, messageId string) error
+	GetDMChannels(userId string) ([]models.DMChannel, error)
+	CreateGroupDM(ownerId, name string, userIds []string) (string, error)
+	GetDMChannel(dmId string) (models.DMChannel, error)
+	GetDMUsers(dmId string) ([]string, error)
+	AddDMUser(dmId, userId string) error
+	RemoveDMUser(dmId, userId string) error
+	UpdateGroupDM(dmId, name, icon string) error
+	CreateDMNotifications(dmId, authorId string) ([]string, error)
+	AddReaction(messageId, channelId, userId, emoji, emojiId string) (bool, error)
+	RemoveReaction(messageId, channelId, userId, emoji, emojiId string) (bool, error)
+	GetReactionUsers(messageId, channelId, emoji, emojiId string, limit int) ([]models.User, error)
+	CreateEmoji(serverId, userId, name, image string) (models.Emoji, error)
+	GetServerEmojis(serverId string) ([]models.Emoji, error)
+	IsServerEmoji(serverId, emojiId string) (bool, error)
+	DeleteEmoji(serverId, emojiId string) error
+	GetMemberRoles(userId, serverId string) ([]string, error)
+	GetChannelServer(channelId string) (models.Server, error)
+	SearchMessages(userId string, scope models.SearchScope, query models.SearchQuery) ([]models.Message, bool, error)
+	GetMessageContext(userId string, message models.Message, size int) ([]models.Message, []models.Message, error)
+	GetMessageRevisions(messageId, channelId string) ([]models.Revision, error)
+	UpdateServerEditHistory(serverId, editHistory string) error
+	UpdateServerMentionableRole(serverId, role string, mentionable bool) ([]string, error)
+	GetServerRoles(serverId string) ([]string, error)
+	GetRoleMembers(serverId string, roles []string) ([]string, error)
+	FilterServerMembers(serverId string, userIds []string) ([]string, error)
+	FilterServerChannels(serverId string, channelIds []string) ([]string, error)
+	PinMessage(channelId, messageId, userId string) (bool, error)
+	UnpinMessage(channelId, messageId string) (bool, error)
+	GetPins(userId, channelId string) ([]models.Pin, error)
+	CreateThread(channelId, messageId, userId, name string, autoArchive int) (models.Thread, error)
+	GetThread(threadId string) (models.Thread, error)
+	GetChannelThreads(channelId string, archived bool) ([]models.Thread, error)
+	UpdateThread(threadId, name string, archived *bool, autoArchive int) (models.Thread, error)
+	TouchThread(threadId, userId string) (*models.Thread, bool, error)
+	JoinThread(threadId, userId string) error
+	LeaveThread(threadId, userId string) error
+	ArchiveInactiveThreads() ([]models.Thread, error)
+	GetChannelMessages(userId, channelId string, page models.MessagePage) ([]models.Message, bool, error)
 	CreateMessage(message models.Message) (models.Message, error)
-	EditMessage(messageId, content string, mentions []string) error
-	DeleteMessage(messageId string) error
+	GetMessageAuthor(messageId, channelId string) (string, error)
+	EditMessage(message models.Message) error
+	DeleteMessage(messageId string) (bool, error)
 	RelateFriends(initiatorId, initiatorUsername, receiverUsername string) (models.FriendRequest, error)
-	AcceptFriend(requestId, notifId string) ([]models.User, error)
-	RefuseFriend(requestId, notifId string) error
+	AcceptFriend(userId, requestId, notifId string) ([]models.User, error)
+	RefuseFriend(userId, requestId, notifId string) error
 	RemoveFriend(userId, FriendId string) error
 	GetNotifications(userId string) (interface{}, error)
+	GetUnreadMessageNotifications(userId string) ([]models.MessageNotif, error)
 	JoinServer(userId, serverId string) (jcServerReturn, error)
 	GetSubscribedChannels(userId string) ([]models.Channel, error)
+	GetSubscribedServerChannels(userId, serverId string) ([]string, error)
 	CreateServer(userId, name string) (jcServerReturn, error)
-	DeleteServer(userId, serverId string) error
-	LeaveServer(userId, serverId string) error
+	DeleteServer(userId, serverId string) ([]string, error)
+	LeaveServer(userId, serverId string) ([]string, error)
 	CreateChannel(serverId, categoryName, channelType, name string) (createChannelReturn, error)
-	RemoveChannel(serverId, categoryName, channelId string) error
+	RemoveChannel(serverId, categoryName, channelId string) ([]string, error)
 	CreateCategory(serverId, name string) error
 	RemoveCategory(serverId, name string) ([]string, error)
 	CreateInvitation(userId, serverId string) (string, error)
 	CreateMessageNotification(userId, channelId string) (models.MessageNotif, error)
 	CreateMessageNotifications(channelId, serverId, authorId string, mentions []string) ([]string, error)
 	UpdateMessageNotifications(userId string, channels []string) error
+	AckChannel(userId, channelId, notifChannel, messageId string) (models.ReadState, error)
+	GetReadStates(userId string) ([]models.ReadState, error)
 	ChangeEmail(userId, email string) error
 	ChangeUsername(userId, username string) error
 	ChangeDisplayName(userId, displayName string) error
@@ -58,6 +106,11 @@ type Service interface {
 	UpdateServerBanner(serverId, bannerLink string) (string, error)
 	CheckInvitationValidity(InviteId string) (models.Invitation, error)
 	UpdateUserStatus(userId string, status string) error
+	UpdatePreferredStatus(userId string, status string) error
+	IsServerMember(userId, serverId string) (bool, error)
+	IsServerChannel(serverId, channelId string) (bool, error)
+	IsChannelMember(userId, channelId string) (bool, error)
+	AreFriends(userId, friendId string) (bool, error)
 }
 
 type service struct {
@@ -188,8 +241,55 @@ func (s *service) DeleteSession(sessionId string) error {
 	return nil
 }
 
+func (s *service) CreateWebsocketTicket(sessionId, userId string) (string, error) {
+	ticket, err := utils.GenerateRandomId(32)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.db.Query(`
+      CREATE ONLY type::thing("ws_tickets", $ticket) CONTENT {
+          session_id: $sessionId,
+          user_id: $userId,
+          expires_at: time::now() + 30s
+      };
+    `, map[string]string{
+		"ticket":    ticket,
+		"sessionId": sessionId,
+		"userId":    userId,
+	})
+	if err != nil {
+		log.Println(err)
+		return "", fmt.Errorf("an error occured while creating the websocket ticket")
+	}
+
+	return ticket, nil
+}
+
+func (s *service) ConsumeWebsocketTicket(ticket string) (models.WebsocketTicket, error) {
+	res, err := s.db.Query(`
+      DELETE type::thing("ws_tickets", $ticket) RETURN BEFORE;
+    `, map[string]string{
+		"ticket": ticket,
+	})
+	if err != nil {
+		log.Println(err)
+		return models.WebsocketTicket{}, err
+	}
+
+	tickets, err := surrealdb.SmartUnmarshal[[]models.WebsocketTicket](res, err)
+	if err != nil {
+		log.Println(err)
+		return models.WebsocketTicket{}, err
+	} else if len(tickets) == 0 {
+		return models.WebsocketTicket{}, fmt.Errorf("this ticket is invalid or has already been used")
+	}
+
+	return tickets[0], nil
+}
+
 func (s *service) GetFriends(userId string) ([]models.User, error) {
-	res, err := s.db.Query(`SELECT VALUE array::distinct((SELECT id, username, display_name, status, avatar, about_me, username_color FROM <->(friends WHERE accepted=true)<->users WHERE id != $userId)) FROM ONLY $userId;`,
+	res, err := s.db.Query(`SELECT VALUE array::distinct((SELECT id, username, display_name, status, last_seen, avatar, about_me, username_color FROM <->(friends WHERE accepted=true)<->users WHERE id != $userId)) FROM ONLY $userId;`,
 		map[string]interface{}{
 			"userId": userId,
 		})
@@ -236,6 +336,8 @@ func (s *service) GetUserServers(userId string) ([]models.Server, error) {
         out.name AS name,
         out.icon AS icon,
         out.banner AS banner,
+        out.edit_history AS edit_history,
+        out.mentionable_roles AS mentionable_roles,
         out.created_at AS created_at
       FROM member WHERE in = $userId ORDER BY created_at ASC FETCH out;
     `, map[string]string{
@@ -255,6 +357,36 @@ func (s *service) GetUserServers(userId string) ([]models.Server, error) {
 	return servers, nil
 }
 
+func (s *service) GetUserServersWithChannels(userId string) ([]models.Server, error) {
+	res, err := s.db.Query(`
+      SELECT
+        roles,
+        out.id AS id,
+        out.name AS name,
+        out.icon AS icon,
+        out.banner AS banner,
+        out.categories AS categories,
+        out.edit_history AS edit_history,
+        out.mentionable_roles AS mentionable_roles,
+        out.created_at AS created_at
+      FROM member WHERE in = $userId ORDER BY created_at ASC FETCH out, categories.channels;
+    `, map[string]string{
+		"userId": userId,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	servers, err := surrealdb.SmartUnmarshal[[]models.Server](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return servers, nil
+}
+
 func (s *service) GetServer(userId, serverId string) (models.Server, error) {
 	res, err := s.db.Query("SELECT * FROM ONLY $serverId FETCH categories.channels", map[string]string{
 		"serverId": serverId,
@@ -311,60 +443,477 @@ type PrivateMessages struct {
 	Members  []models.User    `json:"members"`
 }
 
-func (s *service) GetPrivateMessages(userId, channelId string) ([]models.Message, error) {
+// GetPrivateMessages returns a page of the messages between a user and the
+// friend whose id is used as channel. Reading them doesn't mark them as read,
+// see AckChannel.
+func (s *service) GetPrivateMessages(userId, channelId string, page models.MessagePage) ([]models.Message, bool, error) {
+	return s.getMessages("channel_id = $channelId", map[string]any{
+		"channelId": DMChannelId(userId, "users:"+channelId),
+		"viewer":    userId,
+	}, page)
+}
+
+// DMChannelId returns the id of the conversation between two users, the
+// same whichever of them asks for it.
+func DMChannelId(userId, otherId string) string {
+	ids := []string{strings.Split(userId, ":")[1], strings.Split(otherId, ":")[1]}
+	slices.Sort(ids)
+
+	return "dm_channels:" + ids[0] + "_" + ids[1]
+}
+
+// EnsureDMChannel returns the id of the conversation between two users and
+// whether it had to be created.
+func (s *service) EnsureDMChannel(userId, otherId string) (string, bool, error) {
+	dmId := DMChannelId(userId, otherId)
+
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $exists = (SELECT VALUE id FROM ONLY $dmId);
+      IF !$exists {
+        CREATE $dmId CONTENT {
+          type: 'dm',
+          users: [$userId, $otherId],
+          last_message_at: time::now(),
+        };
+      };
+      RETURN !$exists;
+      COMMIT TRANSACTION;
+    `, map[string]string{
+		"dmId":    dmId,
+		"userId":  userId,
+		"otherId": otherId,
+	})
+	if err != nil {
+		log.Println(err)
+		return "", false, err
+	}
+
+	created, err := surrealdb.SmartUnmarshal[bool](res, err)
+	if err != nil {
+		log.Println(err)
+		return "", false, err
+	}
+
+	return dmId, created, nil
+}
+
+// TouchDMChannel records the last message of a conversation, which orders
+// the conversations of its users.
+func (s *service) TouchDMChannel(dmId, messageId string) error {
+	_, err := s.db.Query("UPDATE $dmId SET last_message_id = $messageId, last_message_at = time::now();", map[string]string{
+		"dmId":      dmId,
+		"messageId": messageId,
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+const dmChannelFields = `id, type, name, icon, owner AS owner_id, last_message_at, created_at`
+
+// MaxGroupDMUsers is how many users a group conversation can hold, its owner
+// included.
+const MaxGroupDMUsers = 10
+
+// CreateGroupDM opens a conversation between its owner and other users, given
+// by their bare ids.
+func (s *service) CreateGroupDM(ownerId, name string, userIds []string) (string, error) {
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $dm = (CREATE ONLY dm_channels CONTENT {
+        type: 'group',
+        name: $name,
+        icon: '',
+        owner: $ownerId,
+        users: [$ownerId],
+        last_message_at: time::now(),
+      });
+      FOR $user IN $userIds {
+        UPDATE $dm.id SET users += type::thing("users", $user);
+      };
+      RETURN $dm.id;
+      COMMIT TRANSACTION;
+    `, map[string]any{
+		"ownerId": ownerId,
+		"name":    name,
+		"userIds": userIds,
+	})
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+
+	dmId, err := surrealdb.SmartUnmarshal[string](res, err)
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+
+	return dmId, nil
+}
+
+// GetDMChannel returns a conversation with all of its users as recipients.
+func (s *service) GetDMChannel(dmId string) (models.DMChannel, error) {
 	res, err := s.db.Query(`
-      SELECT author.id, author.username, author.display_name, author.username_color, author.avatar, channel_id, content, id, edited, images, mentions, updated_at, created_at, replies.id, replies.content, replies.author.display_name
-      FROM messages 
-      WHERE (channel_id = $channelId AND author = $userId) OR (channel_id = $userId2 AND author = $channelId2) ORDER BY created_at ASC FETCH author, replies;
+      SELECT
+        `+dmChannelFields+`,
+        (SELECT id, username, display_name, avatar, status, username_color FROM $parent.users) AS recipients
+      FROM ONLY $dmId;
     `, map[string]string{
-		"userId":     userId,
-		"channelId":  "channels:" + channelId,
-		"userId2":    "channels:" + strings.Split(userId, ":")[1],
-		"channelId2": "users:" + channelId,
+		"dmId": dmId,
+	})
+	if err != nil {
+		log.Println(err)
+		return models.DMChannel{}, err
+	}
+
+	dm, err := surrealdb.SmartUnmarshal[models.DMChannel](res, err)
+	if err != nil {
+		log.Println(err)
+		return models.DMChannel{}, err
+	}
+
+	return dm, nil
+}
+
+// GetDMUsers returns the ids of the users of a conversation.
+func (s *service) GetDMUsers(dmId string) ([]string, error) {
+	res, err := s.db.Query("SELECT VALUE users FROM ONLY $dmId;", map[string]string{
+		"dmId": dmId,
 	})
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	messages, err := surrealdb.SmartUnmarshal[[]models.Message](res, err)
+	users, err := surrealdb.SmartUnmarshal[[]string](res, err)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	_, err = s.db.Query(`
-     UPDATE notifications SET read = true WHERE user_id=$userId AND channel_id=$channelId;
+	return users, nil
+}
+
+// AddDMUser adds a user to a group conversation.
+func (s *service) AddDMUser(dmId, userId string) error {
+	_, err := s.db.Query("UPDATE $dmId SET users += $userId WHERE type = 'group' AND users CONTAINSNOT $userId;", map[string]string{
+		"dmId":   dmId,
+		"userId": userId,
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDMUser removes a user from a group conversation. When the owner
+// leaves, the oldest remaining user takes over, and the conversation is
+// deleted along with its messages once nobody is left.
+func (s *service) RemoveDMUser(dmId, userId string) error {
+	_, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $dm = (UPDATE ONLY $dmId SET users -= $userId WHERE type = 'group' RETURN AFTER);
+      IF $dm AND array::len($dm.users) = 0 {
+        DELETE message_revisions WHERE message_id.channel_id = $dmId;
+        DELETE reactions WHERE message_id.channel_id = $dmId;
+        DELETE messages WHERE channel_id = $dmId;
+        DELETE pins WHERE channel_id = $dmId;
+        DELETE read_states WHERE channel_id = $dmId;
+        DELETE notifications WHERE channel_id = $dmId;
+        DELETE $dmId;
+      } ELSE IF $dm AND $dm.owner = $userId {
+        UPDATE $dmId SET owner = $dm.users[0];
+      };
+      DELETE read_states WHERE user_id = $userId AND channel_id = $dmId;
+      DELETE notifications WHERE user_id = $userId AND channel_id = $dmId;
+      COMMIT TRANSACTION;
     `, map[string]string{
-		"userId":    userId,
-		"channelId": "users:" + channelId,
+		"dmId":   dmId,
+		"userId": userId,
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateGroupDM changes the name or the icon of a group conversation, empty
+// values being left as they are.
+func (s *service) UpdateGroupDM(dmId, name, icon string) error {
+	_, err := s.db.Query(`
+      UPDATE $dmId SET
+        name = IF $name THEN $name ELSE name END,
+        icon = IF $icon THEN $icon ELSE icon END
+      WHERE type = 'group';
+    `, map[string]string{
+		"dmId": dmId,
+		"name": name,
+		"icon": icon,
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateDMNotifications flags a new message in a conversation as unread for
+// its users but the author, returning the users notified.
+func (s *service) CreateDMNotifications(dmId, authorId string) ([]string, error) {
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $users = (SELECT VALUE users FROM ONLY $dmId);
+      FOR $user IN $users {
+        IF $user != $authorId {
+          LET $existingNotif = (SELECT id FROM notifications WHERE user_id = $user AND channel_id = $dmId);
+          IF $existingNotif {
+            UPDATE $existingNotif.id MERGE {
+              read: false,
+            };
+          } ELSE {
+            CREATE ONLY notifications CONTENT {
+              channel_id: $dmId,
+              created_at: time::now(),
+              type: 'new_message',
+              user_id: $user,
+              read: false
+            };
+          };
+        };
+      };
+      RETURN array::complement($users, [$authorId]);
+      COMMIT TRANSACTION;
+    `, map[string]string{
+		"dmId":     dmId,
+		"authorId": authorId,
 	})
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	return messages, nil
+	users, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return users, nil
 }
 
-func (s *service) GetChannelMessages(channelId string, limit, before int) ([]models.Message, error) {
-	res, err := s.db.Query(`SELECT author.id, author.username, author.display_name, author.username_color, author.avatar, channel_id, content, images, mentions, id, edited, updated_at, created_at, replies.id, replies.content, replies.author.display_name FROM messages WHERE channel_id=$channelId ORDER BY created_at DESC LIMIT $limit START $before FETCH author, replies;`, map[string]interface{}{
-		"channelId": "channels:" + channelId,
-		"before":    before,
-		"limit":     limit,
+// GetDMChannels returns the conversations of a user, the most recently
+// active first, with a preview of their last message and how many messages
+// the user hasn't read.
+func (s *service) GetDMChannels(userId string) ([]models.DMChannel, error) {
+	res, err := s.db.Query(`
+      SELECT
+        `+dmChannelFields+`,
+        (SELECT id, username, display_name, avatar, status, username_color FROM $parent.users WHERE id != $userId) AS recipients,
+        IF last_message_id THEN {
+          id: last_message_id,
+          author_id: last_message_id.author,
+          content: last_message_id.content,
+          created_at: last_message_id.created_at,
+        } END AS last_message,
+        count((SELECT id FROM messages WHERE channel_id = $parent.id AND author != $userId AND created_at > (SELECT VALUE last_read_at FROM ONLY read_states WHERE user_id = $userId AND channel_id = $parent.id LIMIT 1))) AS unread_count
+      FROM dm_channels WHERE users CONTAINS $userId ORDER BY last_message_at DESC;
+    `, map[string]string{
+		"userId": userId,
 	})
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	messages, err := surrealdb.SmartUnmarshal[[]models.Message](res, err)
+	channels, err := surrealdb.SmartUnmarshal[[]models.DMChannel](res, err)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	return messages, nil
+	return channels, nil
+}
+
+// GetChannelMessages returns a page of the messages of a channel, their
+// reactions being flagged for userId.
+func (s *service) GetChannelMessages(userId, channelId string, page models.MessagePage) ([]models.Message, bool, error) {
+	return s.getMessages("channel_id = $channelId", map[string]any{
+		"channelId": channelId,
+		"viewer":    userId,
+	}, page)
+}
+
+const messageFields = `author.id, author.username, author.display_name, author.username_color, author.avatar, channel_id, content, images, mentions, mention_roles, mention_channels, mention_everyone, mention_here, id, edited, updated_at, created_at, replies.id, replies.content, replies.author.display_name, ` + reactionFields + `, IF thread THEN (SELECT ` + threadFields + ` FROM ONLY thread) END AS thread`
+
+// reactionFields counts the reactions of a message by emoji, along with the
+// ones added by $viewer.
+const reactionFields = `(SELECT emoji, emoji_id, count() AS count FROM reactions WHERE message_id = $parent.id GROUP BY emoji, emoji_id) AS reactions, (SELECT emoji, emoji_id FROM reactions WHERE message_id = $parent.id AND user_id = $viewer) AS my_reactions`
+
+// messageRow is a message as queried with messageFields, the reactions of
+// the viewer being folded into the Me flags of its reactions.
+type messageRow struct {
+	models.Message
+	MyReactions []models.Reaction `json:"my_reactions"`
+}
+
+func (row messageRow) message() models.Message {
+	for i, reaction := range row.Reactions {
+		row.Reactions[i].Me = slices.ContainsFunc(row.MyReactions, func(mine models.Reaction) bool {
+			return mine.Emoji == reaction.Emoji && mine.EmojiId == reaction.EmojiId
+		})
+	}
+
+	return row.Message
+}
+
+// messageCursor is the position of a message in a conversation. Messages are
+// ordered by creation date, then by id when they share it.
+type messageCursor struct {
+	at string
+	id string
+}
+
+// getMessages returns a page of the messages matching where, newest first,
+// and whether there are more on the side the page was fetched towards, or on
+// either side for pages around a message.
+func (s *service) getMessages(where string, params map[string]any, page models.MessagePage) ([]models.Message, bool, error) {
+	switch {
+	case page.Around != "":
+		cursor, err := s.findMessageCursor(where, params, page.Around)
+		if err != nil {
+			return nil, false, err
+		}
+
+		older, moreBefore, err := s.queryMessages(where, params, &cursor, "<", page.Limit/2)
+		if err != nil {
+			return nil, false, err
+		}
+
+		newer, moreAfter, err := s.queryMessages(where, params, &cursor, ">=", page.Limit-len(older))
+		if err != nil {
+			return nil, false, err
+		}
+
+		return append(newer, older...), moreBefore || moreAfter, nil
+	case page.After != "":
+		cursor, err := s.findMessageCursor(where, params, page.After)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return s.queryMessages(where, params, &cursor, ">", page.Limit)
+	case page.Before != "":
+		cursor, err := s.findMessageCursor(where, params, page.Before)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return s.queryMessages(where, params, &cursor, "<", page.Limit)
+	default:
+		return s.queryMessages(where, params, nil, "<", page.Limit)
+	}
+}
+
+// findMessageCursor resolves a cursor, either a timestamp or the id of a
+// message matching where.
+func (s *service) findMessageCursor(where string, params map[string]any, cursor string) (messageCursor, error) {
+	if _, err := time.Parse(time.RFC3339Nano, cursor); err == nil {
+		return messageCursor{at: cursor}, nil
+	}
+
+	if !strings.HasPrefix(cursor, "messages:") {
+		cursor = "messages:" + cursor
+	}
+
+	query := make(map[string]any, len(params)+1)
+	for k, v := range params {
+		query[k] = v
+	}
+	query["cursorId"] = cursor
+
+	res, err := s.db.Query(`SELECT VALUE created_at FROM ONLY $cursorId WHERE `+where+`;`, query)
+	if err != nil {
+		log.Println(err)
+		return messageCursor{}, err
+	}
+
+	at, err := surrealdb.SmartUnmarshal[string](res, err)
+	if err != nil {
+		log.Println(err)
+		return messageCursor{}, err
+	} else if at == "" {
+		return messageCursor{}, fmt.Errorf("the message doesn't exist in this conversation")
+	}
+
+	return messageCursor{at: at, id: cursor}, nil
+}
+
+// queryMessages returns up to limit messages matching where on one side of a
+// cursor, op being "<" for older messages and ">" or ">=" for newer ones.
+func (s *service) queryMessages(where string, params map[string]any, cursor *messageCursor, op string, limit int) ([]models.Message, bool, error) {
+	if limit <= 0 {
+		return []models.Message{}, false, nil
+	}
+
+	query := make(map[string]any, len(params)+3)
+	for k, v := range params {
+		query[k] = v
+	}
+	query["limit"] = limit + 1
+
+	order := "DESC"
+	if op != "<" {
+		order = "ASC"
+	}
+
+	condition := "true"
+	if cursor != nil && cursor.id != "" {
+		strict := strings.TrimSuffix(op, "=")
+		condition = "(created_at " + strict + " <datetime> $at OR (created_at = <datetime> $at AND id " + op + " $cursorId))"
+		query["at"] = cursor.at
+		query["cursorId"] = cursor.id
+	} else if cursor != nil {
+		condition = "created_at " + op + " <datetime> $at"
+		query["at"] = cursor.at
+	}
+
+	res, err := s.db.Query(`SELECT `+messageFields+` FROM messages WHERE (`+where+`) AND `+condition+` ORDER BY created_at `+order+`, id `+order+` LIMIT $limit FETCH author, replies;`, query)
+	if err != nil {
+		log.Println(err)
+		return nil, false, err
+	}
+
+	rows, err := surrealdb.SmartUnmarshal[[]messageRow](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, false, err
+	}
+
+	messages := make([]models.Message, 0, len(rows))
+	for _, row := range rows {
+		messages = append(messages, row.message())
+	}
+
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+
+	if order == "ASC" {
+		slices.Reverse(messages)
+	}
+
+	return messages, hasMore, nil
 }
 
 type CreateMessage struct {
@@ -374,89 +923,1035 @@ type CreateMessage struct {
 func (s *service) CreateMessage(message models.Message) (models.Message, error) {
 	params := map[string]any{
 		"authorId":  message.Author.ID,
-		"channelId": "channels:" + message.ChannelId,
+		"channelId": message.ChannelId,
 		"content":   message.Content,
 		"edited":    message.Edited,
 		"images":    message.Images,
 		"mentions":  message.Mentions,
+		"everyone":  message.MentionEveryone,
+		"here":      message.MentionHere,
+	}
+
+	if message.Reply.ID != "" {
+		params["reply"] = message.Reply.ID
+	}
+	params["roles"] = message.MentionRoles
+	channels := recordList(params, "channel", message.MentionChannels)
+	mentioned := recordList(params, "mentioned", message.MentionedUsers)
+
+	createRes, err := s.db.Query(`
+    CREATE ONLY messages CONTENT {
+      "author": $authorId,
+      "channel_id": $channelId,
+      "content": $content,
+      "edited": $edited,
+      "images": $images,
+      "mentions": $mentions,
+      "mention_roles": $roles,
+      "mention_channels": `+channels+`,
+      "mention_everyone": $everyone,
+      "mention_here": $here,
+      "mentioned_users": `+mentioned+`,
+      "replies": $reply,
+    } RETURN id;
+    `, params)
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	id, err := surrealdb.SmartUnmarshal[CreateMessage](createRes, err)
+	if err != nil {
+		log.Println(err)
+		return models.Message{}, err
+	}
+
+	messageRes, err := s.db.Query(`
+    SELECT author.id, author.username, author.display_name, author.username_color, author.avatar, channel_id, content, images, mentions, mention_roles, mention_channels, mention_everyone, mention_here, id, edited, updated_at, created_at, replies.id, replies.content, replies.author.display_name FROM ONLY $id FETCH author, replies;
+    `, map[string]any{
+		"id": id.ID,
+	})
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	messageCreated, err := surrealdb.SmartUnmarshal[models.Message](messageRes, err)
+	if err != nil {
+		log.Println(err)
+		return models.Message{}, err
+	}
+
+	return messageCreated, nil
+}
+
+// GetMessageAuthor returns who wrote a message of a channel, or an empty id
+// when the channel has no such message.
+func (s *service) GetMessageAuthor(messageId, channelId string) (string, error) {
+	res, err := s.db.Query("SELECT VALUE author FROM ONLY $messageId WHERE channel_id = $channelId;", map[string]string{
+		"messageId": messageId,
+		"channelId": channelId,
+	})
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+
+	authorId, err := surrealdb.SmartUnmarshal[*string](res, err)
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+	if authorId == nil {
+		return "", nil
+	}
+
+	return *authorId, nil
+}
+
+// EditMessage changes the content and the mentions of a message, keeping
+// what it replaces as a revision.
+func (s *service) EditMessage(message models.Message) error {
+	params := map[string]any{
+		"messageId": message.ID,
+		"content":   message.Content,
+		"mentions":  message.Mentions,
+		"roles":     message.MentionRoles,
+		"everyone":  message.MentionEveryone,
+		"here":      message.MentionHere,
+	}
+	channels := recordList(params, "channel", message.MentionChannels)
+	mentioned := recordList(params, "mentioned", message.MentionedUsers)
+
+	_, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $previous = (SELECT content, mentions, updated_at, created_at FROM ONLY $messageId);
+      IF $previous {
+        CREATE message_revisions CONTENT {
+          message_id: $messageId,
+          content: $previous.content,
+          mentions: $previous.mentions ?? [],
+          written_at: IF $previous.updated_at THEN $previous.updated_at ELSE $previous.created_at END,
+        };
+      };
+      UPDATE $messageId MERGE {
+          content: $content,
+          edited: true,
+          mentions: $mentions,
+          mention_roles: $roles,
+          mention_channels: `+channels+`,
+          mention_everyone: $everyone,
+          mention_here: $here,
+          mentioned_users: `+mentioned+`,
+          updated_at: time::now()
+      };
+      COMMIT TRANSACTION;
+    `, params)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// recordList adds the ids as numbered parameters so each one is sent as a
+// record, returning them as an array to use in a query.
+func recordList(params map[string]any, name string, ids []string) string {
+	list := make([]string, 0, len(ids))
+	for i, id := range ids {
+		param := fmt.Sprintf("%s%d", name, i)
+		params[param] = id
+		list = append(list, "$"+param)
+	}
+
+	return "[" + strings.Join(list, ", ") + "]"
+}
+
+// DeleteMessage deletes a message along with its reactions, pin and
+// revisions, reporting whether it was pinned.
+func (s *service) DeleteMessage(messageId string) (bool, error) {
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      DELETE $messageId;
+      DELETE reactions WHERE message_id = $messageId;
+      LET $pins = (DELETE pins WHERE message_id = $messageId RETURN BEFORE);
+      DELETE message_revisions WHERE message_id = $messageId;
+      RETURN array::len($pins) > 0;
+      COMMIT TRANSACTION;
+    `, map[string]any{
+		"messageId": messageId,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return surrealdb.SmartUnmarshal[bool](res, err)
+}
+
+// AddReaction reacts to a message of a channel with a unicode emoji, or with
+// a custom emoji when emojiId is set. It reports whether the reaction is new.
+func (s *service) AddReaction(messageId, channelId, userId, emoji, emojiId string) (bool, error) {
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $message = (SELECT VALUE id FROM ONLY $messageId WHERE channel_id = $channelId);
+      LET $existing = (SELECT VALUE id FROM reactions WHERE message_id = $messageId AND user_id = $userId AND emoji = $emoji AND emoji_id = $emojiId);
+      IF $message AND !$existing {
+        CREATE reactions CONTENT {
+          message_id: $messageId,
+          user_id: $userId,
+          emoji: $emoji,
+          emoji_id: $emojiId,
+        };
+      };
+      RETURN IF $message THEN !$existing ELSE NONE END;
+      COMMIT TRANSACTION;
+    `, map[string]string{
+		"messageId": messageId,
+		"channelId": channelId,
+		"userId":    userId,
+		"emoji":     emoji,
+		"emojiId":   emojiId,
+	})
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	added, err := surrealdb.SmartUnmarshal[*bool](res, err)
+	if err != nil {
+		log.Println(err)
+		return false, err
+	} else if added == nil {
+		return false, fmt.Errorf("this message doesn't exist")
+	}
+
+	return *added, nil
+}
+
+// RemoveReaction removes the reaction of a user to a message, reporting
+// whether there was one.
+func (s *service) RemoveReaction(messageId, channelId, userId, emoji, emojiId string) (bool, error) {
+	res, err := s.db.Query("DELETE reactions WHERE message_id = $messageId AND message_id.channel_id = $channelId AND user_id = $userId AND emoji = $emoji AND emoji_id = $emojiId RETURN BEFORE;", map[string]string{
+		"messageId": messageId,
+		"channelId": channelId,
+		"userId":    userId,
+		"emoji":     emoji,
+		"emojiId":   emojiId,
+	})
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	removed, err := surrealdb.SmartUnmarshal[[]map[string]any](res, err)
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	return len(removed) > 0, nil
+}
+
+// GetReactionUsers lists the first users who reacted to a message of a
+// channel with an emoji, in the order they did.
+func (s *service) GetReactionUsers(messageId, channelId, emoji, emojiId string, limit int) ([]models.User, error) {
+	res, err := s.db.Query(`
+      SELECT user_id.id AS id, user_id.username AS username, user_id.display_name AS display_name, user_id.avatar AS avatar, user_id.username_color AS username_color, created_at AS reacted_at
+      FROM reactions WHERE message_id = $messageId AND message_id.channel_id = $channelId AND emoji = $emoji AND emoji_id = $emojiId
+      ORDER BY reacted_at LIMIT $limit;
+    `, map[string]any{
+		"messageId": messageId,
+		"channelId": channelId,
+		"emoji":     emoji,
+		"emojiId":   emojiId,
+		"limit":     limit,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	users, err := surrealdb.SmartUnmarshal[[]models.User](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// MaxServerEmojis is how many custom emojis a server can have.
+const MaxServerEmojis = 50
+
+var ErrTooManyEmojis = fmt.Errorf("a server can't have more than %d emojis", MaxServerEmojis)
+
+const emojiFields = `meta::id(id) AS id, name, image, created_by, created_at`
+
+type createEmojiReturn struct {
+	Status string       `json:"status"`
+	Emoji  models.Emoji `json:"emoji"`
+}
+
+// CreateEmoji adds a custom emoji to a server, its name being unique there.
+func (s *service) CreateEmoji(serverId, userId, name, image string) (models.Emoji, error) {
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $count = count(SELECT id FROM emojis WHERE server = $serverId);
+      LET $taken = (SELECT VALUE id FROM emojis WHERE server = $serverId AND name = $name);
+      LET $status = IF $count >= $max {
+        'full';
+      } ELSE IF $taken {
+        'taken';
+      } ELSE {
+        'added';
+      };
+      LET $emoji = IF $status = 'added' {
+        (CREATE ONLY emojis CONTENT {
+          server: $serverId,
+          name: $name,
+          image: $image,
+          created_by: $userId,
+        }).id;
+      };
+      RETURN {
+        status: $status,
+        emoji: IF $emoji THEN (SELECT `+emojiFields+` FROM ONLY $emoji) END,
+      };
+      COMMIT TRANSACTION;
+    `, map[string]any{
+		"serverId": serverId,
+		"userId":   userId,
+		"name":     name,
+		"image":    image,
+		"max":      MaxServerEmojis,
+	})
+	if err != nil {
+		log.Println(err)
+		return models.Emoji{}, err
+	}
+
+	created, err := surrealdb.SmartUnmarshal[createEmojiReturn](res, err)
+	if err != nil {
+		log.Println(err)
+		return models.Emoji{}, err
+	}
+
+	switch created.Status {
+	case "full":
+		return models.Emoji{}, ErrTooManyEmojis
+	case "taken":
+		return models.Emoji{}, fmt.Errorf("this server already has an emoji named %s", name)
+	}
+
+	return created.Emoji, nil
+}
+
+func (s *service) GetServerEmojis(serverId string) ([]models.Emoji, error) {
+	res, err := s.db.Query("SELECT "+emojiFields+" FROM emojis WHERE server = $serverId ORDER BY created_at;", map[string]string{
+		"serverId": serverId,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	emojis, err := surrealdb.SmartUnmarshal[[]models.Emoji](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return emojis, nil
+}
+
+// IsServerEmoji tells whether a custom emoji, given by its bare id, belongs
+// to a server.
+func (s *service) IsServerEmoji(serverId, emojiId string) (bool, error) {
+	res, err := s.db.Query(`RETURN array::len(SELECT id FROM emojis WHERE id = type::thing("emojis", $emojiId) AND server = $serverId) > 0;`, map[string]string{
+		"serverId": serverId,
+		"emojiId":  emojiId,
+	})
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	return surrealdb.SmartUnmarshal[bool](res, err)
+}
+
+// DeleteEmoji removes a custom emoji of a server along with the reactions
+// made with it.
+func (s *service) DeleteEmoji(serverId, emojiId string) error {
+	_, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $emoji = (SELECT VALUE id FROM emojis WHERE id = type::thing("emojis", $emojiId) AND server = $serverId);
+      IF $emoji {
+        DELETE $emoji;
+        DELETE reactions WHERE emoji_id = $emojiId;
+      };
+      COMMIT TRANSACTION;
+    `, map[string]string{
+		"serverId": serverId,
+		"emojiId":  emojiId,
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetMemberRoles returns the roles of a user in a server, nil when they
+// aren't a member.
+func (s *service) GetMemberRoles(userId, serverId string) ([]string, error) {
+	res, err := s.db.Query("SELECT VALUE roles FROM ONLY member WHERE in = $userId AND out = $serverId LIMIT 1;", map[string]string{
+		"userId":   userId,
+		"serverId": serverId,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	roles, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// GetChannelServer returns the id and the settings of the server a channel,
+// or the parent channel of a thread, belongs to.
+func (s *service) GetChannelServer(channelId string) (models.Server, error) {
+	res, err := s.db.Query(`
+      SELECT id, name, edit_history, mentionable_roles FROM ONLY servers
+      WHERE array::flatten(categories.channels) CONTAINS (IF $channelId.parent_channel THEN $channelId.parent_channel ELSE $channelId END) LIMIT 1;
+    `, map[string]string{
+		"channelId": channelId,
+	})
+	if err != nil {
+		log.Println(err)
+		return models.Server{}, err
+	}
+
+	server, err := surrealdb.SmartUnmarshal[models.Server](res, err)
+	if err != nil {
+		log.Println(err)
+		return models.Server{}, err
+	}
+
+	return server, nil
+}
+
+// SearchMessages returns a page of the messages matching a search in the
+// channels of a scope the user can read, the newest first.
+func (s *service) SearchMessages(userId string, scope models.SearchScope, query models.SearchQuery) ([]models.Message, bool, error) {
+	params := map[string]any{
+		"userId": userId,
+		"viewer": userId,
+		"limit":  query.Limit + 1,
+		"offset": query.Offset,
+	}
+
+	var channels string
+	switch {
+	case scope.DMs:
+		channels = `(SELECT VALUE id FROM dm_channels WHERE users CONTAINS $userId)`
+	case scope.ChannelId != "":
+		channels = `[$channelId]`
+		params["channelId"] = scope.ChannelId
+	default:
+		channels = `(SELECT VALUE out FROM subscribed WHERE in = $userId AND out IN (SELECT VALUE array::flatten(categories.channels) FROM ONLY $serverId))`
+		params["serverId"] = scope.ServerId
+	}
+
+	conditions := []string{"(channel_id IN $channels OR channel_id.parent_channel IN $channels)"}
+	anyOf := func(format, name string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+
+		var or []string
+		for i, value := range values {
+			param := fmt.Sprintf("%s%d", name, i)
+			params[param] = value
+			or = append(or, fmt.Sprintf(format, "$"+param))
+		}
+		conditions = append(conditions, "("+strings.Join(or, " OR ")+")")
+	}
+
+	if query.Text != "" {
+		conditions = append(conditions, "content @@ $text")
+		params["text"] = query.Text
+	}
+	anyOf("author = %s", "from", query.From)
+	anyOf("channel_id = %s", "in", query.In)
+	anyOf("mentions CONTAINS %s", "mention", query.Mentions)
+	for _, has := range query.Has {
+		switch has {
+		case "image":
+			conditions = append(conditions, "array::len(images) > 0")
+		case "link":
+			conditions = append(conditions, "(string::contains(content, 'http://') OR string::contains(content, 'https://'))")
+		case "reaction":
+			conditions = append(conditions, "count((SELECT id FROM reactions WHERE message_id = $parent.id)) > 0")
+		}
+	}
+	if query.Before != "" {
+		conditions = append(conditions, "created_at < <datetime> $before")
+		params["before"] = query.Before
+	}
+	if query.After != "" {
+		conditions = append(conditions, "created_at > <datetime> $after")
+		params["after"] = query.After
+	}
+
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $channels = `+channels+`;
+      RETURN (SELECT `+messageFields+` FROM messages WHERE `+strings.Join(conditions, " AND ")+` ORDER BY created_at DESC, id DESC LIMIT $limit START $offset FETCH author, replies);
+      COMMIT TRANSACTION;
+    `, params)
+	if err != nil {
+		log.Println(err)
+		return nil, false, err
+	}
+
+	rows, err := surrealdb.SmartUnmarshal[[]messageRow](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, false, err
+	}
+
+	messages := make([]models.Message, 0, len(rows))
+	for _, row := range rows {
+		messages = append(messages, row.message())
+	}
+
+	hasMore := len(messages) > query.Limit
+	if hasMore {
+		messages = messages[:query.Limit]
+	}
+
+	return messages, hasMore, nil
+}
+
+// GetMessageContext returns up to size messages before and after a message
+// of its channel, the newest first like pages of messages.
+func (s *service) GetMessageContext(userId string, message models.Message, size int) ([]models.Message, []models.Message, error) {
+	params := map[string]any{
+		"channelId": message.ChannelId,
+		"viewer":    userId,
+	}
+	cursor := messageCursor{at: message.CreatedAt, id: message.ID}
+
+	before, _, err := s.queryMessages("channel_id = $channelId", params, &cursor, "<", size)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	after, _, err := s.queryMessages("channel_id = $channelId", params, &cursor, ">", size)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return before, after, nil
+}
+
+// GetMessageRevisions returns the previous versions of a message of a
+// channel, the oldest first.
+func (s *service) GetMessageRevisions(messageId, channelId string) ([]models.Revision, error) {
+	res, err := s.db.Query(`
+      SELECT content, mentions, written_at, created_at AS replaced_at FROM message_revisions
+      WHERE message_id = $messageId AND message_id.channel_id = $channelId ORDER BY replaced_at ASC;
+    `, map[string]string{
+		"messageId": messageId,
+		"channelId": channelId,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	revisions, err := surrealdb.SmartUnmarshal[[]models.Revision](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (s *service) UpdateServerEditHistory(serverId, editHistory string) error {
+	_, err := s.db.Query("UPDATE $serverId SET edit_history = $editHistory;", map[string]string{
+		"serverId":    serverId,
+		"editHistory": editHistory,
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateServerMentionableRole lets every member of a server mention a role,
+// or only its moderators, returning the roles that are now mentionable.
+func (s *service) UpdateServerMentionableRole(serverId, role string, mentionable bool) ([]string, error) {
+	res, err := s.db.Query(`
+      UPDATE ONLY $serverId SET mentionable_roles = IF $mentionable
+        THEN array::union(mentionable_roles ?? [], [$role])
+        ELSE array::complement(mentionable_roles ?? [], [$role])
+      END RETURN VALUE mentionable_roles;
+    `, map[string]any{
+		"serverId":    serverId,
+		"role":        role,
+		"mentionable": mentionable,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	roles, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// GetServerRoles returns every role given to a member of a server.
+func (s *service) GetServerRoles(serverId string) ([]string, error) {
+	res, err := s.db.Query("SELECT VALUE array::distinct(array::flatten(<-member.roles)) FROM ONLY $serverId;", map[string]string{
+		"serverId": serverId,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	roles, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// GetRoleMembers returns the members of a server having any of the roles.
+func (s *service) GetRoleMembers(serverId string, roles []string) ([]string, error) {
+	res, err := s.db.Query("SELECT VALUE in FROM member WHERE out = $serverId AND roles ANYINSIDE $roles;", map[string]any{
+		"serverId": serverId,
+		"roles":    roles,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	users, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// FilterServerMembers returns which of the users are members of a server.
+func (s *service) FilterServerMembers(serverId string, userIds []string) ([]string, error) {
+	params := map[string]any{
+		"serverId": serverId,
+	}
+	users := recordList(params, "user", userIds)
+
+	res, err := s.db.Query("SELECT VALUE in FROM member WHERE out = $serverId AND in IN "+users+";", params)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	members, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// FilterServerChannels returns which of the channels, or threads of its
+// channels, belong to a server.
+func (s *service) FilterServerChannels(serverId string, channelIds []string) ([]string, error) {
+	params := map[string]any{
+		"serverId": serverId,
+	}
+	channels := recordList(params, "channel", channelIds)
+
+	res, err := s.db.Query(`
+      SELECT VALUE id FROM channels
+      WHERE id IN `+channels+` AND array::flatten($serverId.categories.channels) CONTAINS (IF parent_channel THEN parent_channel ELSE id END);
+    `, params)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	found, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return found, nil
+}
+
+// MaxPins is how many messages can be pinned in a channel.
+const MaxPins = 50
+
+var ErrTooManyPins = fmt.Errorf("a channel can't have more than %d pinned messages", MaxPins)
+
+// PinMessage pins a message of a channel, reporting whether it wasn't
+// pinned yet.
+func (s *service) PinMessage(channelId, messageId, userId string) (bool, error) {
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $message = (SELECT VALUE id FROM ONLY $messageId WHERE channel_id = $channelId);
+      LET $existing = (SELECT VALUE id FROM pins WHERE channel_id = $channelId AND message_id = $messageId);
+      LET $count = count(SELECT id FROM pins WHERE channel_id = $channelId);
+      LET $status = IF !$message {
+        'missing';
+      } ELSE IF $existing {
+        'pinned';
+      } ELSE IF $count >= $max {
+        'full';
+      } ELSE {
+        'added';
+      };
+      IF $status = 'added' {
+        CREATE pins CONTENT {
+          channel_id: $channelId,
+          message_id: $messageId,
+          pinned_by: $userId,
+        };
+      };
+      RETURN $status;
+      COMMIT TRANSACTION;
+    `, map[string]any{
+		"channelId": channelId,
+		"messageId": messageId,
+		"userId":    userId,
+		"max":       MaxPins,
+	})
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	status, err := surrealdb.SmartUnmarshal[string](res, err)
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	switch status {
+	case "missing":
+		return false, fmt.Errorf("this message doesn't exist")
+	case "full":
+		return false, ErrTooManyPins
+	}
+
+	return status == "added", nil
+}
+
+// UnpinMessage unpins a message of a channel, reporting whether it was
+// pinned.
+func (s *service) UnpinMessage(channelId, messageId string) (bool, error) {
+	res, err := s.db.Query("DELETE pins WHERE channel_id = $channelId AND message_id = $messageId RETURN BEFORE;", map[string]string{
+		"channelId": channelId,
+		"messageId": messageId,
+	})
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	removed, err := surrealdb.SmartUnmarshal[[]map[string]any](res, err)
+	if err != nil {
+		log.Println(err)
+		return false, err
+	}
+
+	return len(removed) > 0, nil
+}
+
+type pinRow struct {
+	Message  messageRow `json:"message"`
+	PinnedBy string     `json:"pinned_by"`
+	PinnedAt string     `json:"pinned_at"`
+}
+
+// GetPins returns the pinned messages of a channel, the last pinned first.
+func (s *service) GetPins(userId, channelId string) ([]models.Pin, error) {
+	res, err := s.db.Query(`
+      SELECT
+        (SELECT `+messageFields+` FROM ONLY $parent.message_id FETCH author, replies) AS message,
+        pinned_by,
+        pinned_at
+      FROM pins WHERE channel_id = $channelId ORDER BY pinned_at DESC;
+    `, map[string]string{
+		"channelId": channelId,
+		"viewer":    userId,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	rows, err := surrealdb.SmartUnmarshal[[]pinRow](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	pins := make([]models.Pin, 0, len(rows))
+	for _, row := range rows {
+		pins = append(pins, models.Pin{
+			Message:  row.Message.message(),
+			PinnedBy: row.PinnedBy,
+			PinnedAt: row.PinnedAt,
+		})
+	}
+
+	return pins, nil
+}
+
+const threadFields = `id, name, parent_channel AS parent_channel_id, parent_message AS parent_message_id, owner AS owner_id, message_count, last_message_at, archived, auto_archive, created_at`
+
+// CreateThread starts a thread from a message of a channel, the user who
+// starts it being its owner and first subscriber. A message can only start
+// one thread.
+func (s *service) CreateThread(channelId, messageId, userId, name string, autoArchive int) (models.Thread, error) {
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $message = (SELECT id, thread FROM ONLY $messageId WHERE channel_id = $channelId);
+      LET $thread = IF $message AND !$message.thread {
+        (CREATE ONLY channels CONTENT {
+          name: $name,
+          type: 'thread',
+          private: false,
+          parent_channel: $channelId,
+          parent_message: $messageId,
+          owner: $userId,
+          message_count: 0,
+          last_message_at: time::now(),
+          archived: false,
+          auto_archive: $autoArchive,
+        }).id;
+      };
+      IF $thread {
+        UPDATE $messageId SET thread = $thread;
+        RELATE $userId->subscribed->$thread;
+      };
+      RETURN IF $thread THEN (SELECT `+threadFields+` FROM ONLY $thread) ELSE NONE END;
+      COMMIT TRANSACTION;
+    `, map[string]any{
+		"channelId":   channelId,
+		"messageId":   messageId,
+		"userId":      userId,
+		"name":        name,
+		"autoArchive": autoArchive,
+	})
+	if err != nil {
+		log.Println(err)
+		return models.Thread{}, err
+	}
+
+	thread, err := surrealdb.SmartUnmarshal[*models.Thread](res, err)
+	if err != nil {
+		log.Println(err)
+		return models.Thread{}, err
+	} else if thread == nil {
+		return models.Thread{}, fmt.Errorf("this message doesn't exist or already has a thread")
+	}
+
+	return *thread, nil
+}
+
+func (s *service) GetThread(threadId string) (models.Thread, error) {
+	res, err := s.db.Query("SELECT "+threadFields+" FROM ONLY $threadId WHERE type = 'thread';", map[string]string{
+		"threadId": threadId,
+	})
+	if err != nil {
+		log.Println(err)
+		return models.Thread{}, err
+	}
+
+	thread, err := surrealdb.SmartUnmarshal[*models.Thread](res, err)
+	if err != nil {
+		log.Println(err)
+		return models.Thread{}, err
+	} else if thread == nil {
+		return models.Thread{}, fmt.Errorf("this thread doesn't exist")
+	}
+
+	return *thread, nil
+}
+
+// GetChannelThreads returns the active or the archived threads of a channel,
+// the most recently active first.
+func (s *service) GetChannelThreads(channelId string, archived bool) ([]models.Thread, error) {
+	res, err := s.db.Query("SELECT "+threadFields+" FROM channels WHERE type = 'thread' AND parent_channel = $channelId AND archived = $archived ORDER BY last_message_at DESC;", map[string]any{
+		"channelId": channelId,
+		"archived":  archived,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
 	}
 
-	if message.Reply.ID != "" {
-		params["reply"] = message.Reply.ID
+	threads, err := surrealdb.SmartUnmarshal[[]models.Thread](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
 	}
 
-	createRes, err := s.db.Query(`
-    CREATE ONLY messages CONTENT {
-      "author": $authorId,
-      "channel_id": $channelId,
-      "content": $content,
-      "edited": $edited,
-      "images": $images,
-      "mentions": $mentions,
-      "replies": $reply,
-    } RETURN id;
-    `, params)
+	return threads, nil
+}
+
+// UpdateThread renames, archives or changes the auto archive delay of a
+// thread, empty values being left as they are.
+func (s *service) UpdateThread(threadId, name string, archived *bool, autoArchive int) (models.Thread, error) {
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      UPDATE $threadId SET
+        name = IF $name THEN $name ELSE name END,
+        archived = IF $archived != NONE THEN $archived ELSE archived END,
+        auto_archive = IF $autoArchive THEN $autoArchive ELSE auto_archive END,
+        last_message_at = IF $archived = false THEN time::now() ELSE last_message_at END
+      WHERE type = 'thread';
+      RETURN SELECT `+threadFields+` FROM ONLY $threadId;
+      COMMIT TRANSACTION;
+    `, map[string]any{
+		"threadId":    threadId,
+		"name":        name,
+		"archived":    archived,
+		"autoArchive": autoArchive,
+	})
 	if err != nil {
-		return models.Message{}, err
+		log.Println(err)
+		return models.Thread{}, err
 	}
 
-	id, err := surrealdb.SmartUnmarshal[CreateMessage](createRes, err)
+	thread, err := surrealdb.SmartUnmarshal[models.Thread](res, err)
 	if err != nil {
 		log.Println(err)
-		return models.Message{}, err
+		return models.Thread{}, err
 	}
 
-	messageRes, err := s.db.Query(`
-    SELECT author.id, author.username, author.display_name, author.username_color, author.avatar, channel_id, content, images, mentions, id, edited, updated_at, created_at, replies.id, replies.content, replies.author.display_name FROM ONLY $id FETCH author, replies;
-    `, map[string]any{
-		"id": id.ID,
+	return thread, nil
+}
+
+type touchThreadReturn struct {
+	Thread *models.Thread `json:"thread"`
+	Joined bool           `json:"joined"`
+}
+
+// TouchThread records a new message in a channel when it is a thread, which
+// brings it back from the archives and subscribes its author. It returns
+// nil for other channels, and whether the author just joined the thread.
+func (s *service) TouchThread(threadId, userId string) (*models.Thread, bool, error) {
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $thread = (SELECT VALUE id FROM ONLY $threadId WHERE type = 'thread');
+      LET $joined = $thread != NONE AND array::len(SELECT id FROM subscribed WHERE in = $userId AND out = $thread) = 0;
+      IF $joined {
+        RELATE $userId->subscribed->$thread;
+      };
+      IF $thread {
+        UPDATE $thread SET message_count += 1, last_message_at = time::now(), archived = false;
+      };
+      RETURN {
+        thread: IF $thread THEN (SELECT `+threadFields+` FROM ONLY $thread) END,
+        joined: $joined,
+      };
+      COMMIT TRANSACTION;
+    `, map[string]string{
+		"threadId": threadId,
+		"userId":   userId,
 	})
 	if err != nil {
-		return models.Message{}, err
+		log.Println(err)
+		return nil, false, err
 	}
 
-	messageCreated, err := surrealdb.SmartUnmarshal[models.Message](messageRes, err)
+	touched, err := surrealdb.SmartUnmarshal[touchThreadReturn](res, err)
 	if err != nil {
 		log.Println(err)
-		return models.Message{}, err
+		return nil, false, err
 	}
 
-	return messageCreated, nil
+	return touched.Thread, touched.Joined, nil
 }
 
-func (s *service) EditMessage(messageId, content string, mentions []string) error {
+func (s *service) JoinThread(threadId, userId string) error {
 	_, err := s.db.Query(`
-      UPDATE $messageId MERGE {
-          content: $content,
-          edited: true,
-          mentions: $mentions,
-          updated_at: time::now()
-      }
-    `, map[string]any{
-		"messageId": messageId,
-		"mentions":  mentions,
-		"content":   content,
+      IF array::len(SELECT id FROM subscribed WHERE in = $userId AND out = $threadId) = 0 {
+        RELATE $userId->subscribed->$threadId;
+      };
+    `, map[string]string{
+		"threadId": threadId,
+		"userId":   userId,
 	})
 	if err != nil {
+		log.Println(err)
 		return err
 	}
 
 	return nil
 }
 
-func (s *service) DeleteMessage(messageId string) error {
-	_, err := s.db.Query(`
-      DELETE $messageId;
-    `, map[string]any{
-		"messageId": messageId,
+func (s *service) LeaveThread(threadId, userId string) error {
+	_, err := s.db.Query("DELETE subscribed WHERE in = $userId AND out = $threadId AND out.type = 'thread';", map[string]string{
+		"threadId": threadId,
+		"userId":   userId,
 	})
 	if err != nil {
+		log.Println(err)
 		return err
 	}
 
 	return nil
 }
 
+// ArchiveInactiveThreads archives the threads without messages for longer
+// than their auto archive delay, in minutes, and returns them.
+func (s *service) ArchiveInactiveThreads() ([]models.Thread, error) {
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $archived = (UPDATE channels SET archived = true
+        WHERE type = 'thread' AND archived = false AND time::now() - last_message_at > duration::from::mins(auto_archive));
+      RETURN SELECT `+threadFields+` FROM $archived.id;
+      COMMIT TRANSACTION;
+    `, nil)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	threads, err := surrealdb.SmartUnmarshal[[]models.Thread](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return threads, nil
+}
+
 func (s *service) CreateMessageNotifications(channelId, serverId, authorId string, mentions []string) ([]string, error) {
 	createRes, err := s.db.Query(`
       BEGIN TRANSACTION;
@@ -519,6 +2014,90 @@ func (s *service) UpdateMessageNotifications(userId string, channels []string) e
 	return nil
 }
 
+// readStateFields selects a read state along with the number of messages
+// and mentions received in its channel since it was last read.
+const readStateFields = `
+      channel_id, last_message_id, last_read_at,
+      count((SELECT id FROM messages WHERE channel_id = $parent.channel_id AND created_at > $parent.last_read_at AND author != $parent.user_id)) AS unread_count,
+      count((SELECT id FROM messages WHERE channel_id = $parent.channel_id AND created_at > $parent.last_read_at AND author != $parent.user_id AND (mentioned_users CONTAINS $parent.user_id OR mentions CONTAINS $parent.user_id))) AS mention_count
+`
+
+// AckChannel marks a channel or a conversation as read up to a message,
+// along with the notifications of notifChannel, which is the author for
+// private messages. Read states only move forward, acknowledging an older
+// message leaves them as they are.
+var ErrMessageNotFound = fmt.Errorf("the message doesn't exist in this channel")
+
+func (s *service) AckChannel(userId, channelId, notifChannel, messageId string) (models.ReadState, error) {
+	params := map[string]any{
+		"userId":       userId,
+		"messageId":    messageId,
+		"channelId":    channelId,
+		"notifChannel": notifChannel,
+	}
+
+	res, err := s.db.Query(`
+      BEGIN TRANSACTION;
+      LET $message = (SELECT id, created_at FROM ONLY $messageId WHERE channel_id = $channelId);
+      LET $existing = (SELECT id, last_read_at FROM ONLY read_states WHERE user_id = $userId AND channel_id = $channelId LIMIT 1);
+      LET $state = IF !$message {
+        NONE;
+      } ELSE IF !$existing {
+        (CREATE ONLY read_states CONTENT {
+          user_id: $userId,
+          channel_id: $channelId,
+          last_message_id: $message.id,
+          last_read_at: $message.created_at,
+        }).id;
+      } ELSE IF $existing.last_read_at < $message.created_at {
+        (UPDATE ONLY $existing.id MERGE {
+          last_message_id: $message.id,
+          last_read_at: $message.created_at,
+          updated_at: time::now(),
+        }).id;
+      } ELSE {
+        $existing.id;
+      };
+      IF $state {
+        UPDATE notifications SET read = true WHERE user_id = $userId AND channel_id = $notifChannel;
+      };
+      RETURN IF $state THEN (SELECT `+readStateFields+` FROM ONLY $state) ELSE NONE END;
+      COMMIT TRANSACTION;
+    `, params)
+	if err != nil {
+		log.Println(err)
+		return models.ReadState{}, err
+	}
+
+	state, err := surrealdb.SmartUnmarshal[*models.ReadState](res, err)
+	if err != nil {
+		log.Println(err)
+		return models.ReadState{}, err
+	} else if state == nil {
+		return models.ReadState{}, ErrMessageNotFound
+	}
+
+	return *state, nil
+}
+
+func (s *service) GetReadStates(userId string) ([]models.ReadState, error) {
+	res, err := s.db.Query(`SELECT `+readStateFields+` FROM read_states WHERE user_id = $userId;`, map[string]string{
+		"userId": userId,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	states, err := surrealdb.SmartUnmarshal[[]models.ReadState](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return states, nil
+}
+
 func (s *service) CreateMessageNotification(userId, channelId string) (models.MessageNotif, error) {
 	createRes, err := s.db.Query(`
       BEGIN TRANSACTION;
@@ -631,10 +2210,15 @@ func (s *service) RelateFriends(initiatorId, initiatorUsername, receiverUsername
 	return notif, nil
 }
 
-func (s *service) AcceptFriend(requestId, notifId string) ([]models.User, error) {
+func (s *service) AcceptFriend(userId, requestId, notifId string) ([]models.User, error) {
 	res, err := s.db.Query(`
       BEGIN TRANSACTION;
-      LET $users = SELECT initiator_id, user_id FROM ONLY $notifId;
+      LET $users = SELECT initiator_id, user_id, request_id FROM ONLY $notifId;
+
+      IF $users.user_id != $userId OR $users.request_id != $requestId {
+          THROW "This friend request is not addressed to you."
+      };
+
       LET $initiator = SELECT id, username, display_name, status, avatar, about_me FROM ONLY $users.initiator_id;  
       LET $receiver = SELECT id, username, display_name, status, avatar, about_me FROM ONLY $users.user_id;
 
@@ -644,6 +2228,7 @@ func (s *service) AcceptFriend(requestId, notifId string) ([]models.User, error)
       RETURN [$initiator, $receiver];
       COMMIT TRANSACTION;
     `, map[string]string{
+		"userId":    userId,
 		"requestId": requestId,
 		"notifId":   notifId,
 	})
@@ -661,11 +2246,20 @@ func (s *service) AcceptFriend(requestId, notifId string) ([]models.User, error)
 	return users, nil
 }
 
-func (s *service) RefuseFriend(requestId, notifId string) error {
+func (s *service) RefuseFriend(userId, requestId, notifId string) error {
 	_, err := s.db.Query(`
-    DELETE $requestId;
-    DELETE $notifId;
+      BEGIN TRANSACTION;
+      LET $notif = SELECT user_id, request_id FROM ONLY $notifId;
+
+      IF $notif.user_id != $userId OR $notif.request_id != $requestId {
+          THROW "This friend request is not addressed to you."
+      };
+
+      DELETE $requestId;
+      DELETE $notifId;
+      COMMIT TRANSACTION;
     `, map[string]string{
+		"userId":    userId,
 		"requestId": requestId,
 		"notifId":   notifId,
 	})
@@ -711,6 +2305,24 @@ func (s *service) GetNotifications(userId string) (interface{}, error) {
 	return notifs, err
 }
 
+func (s *service) GetUnreadMessageNotifications(userId string) ([]models.MessageNotif, error) {
+	res, err := s.db.Query("SELECT * FROM notifications WHERE user_id=$userId AND type='new_message' AND read=false;", map[string]string{
+		"userId": userId,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	notifs, err := surrealdb.SmartUnmarshal[[]models.MessageNotif](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return notifs, nil
+}
+
 func (s *service) GetSubscribedChannels(userId string) ([]models.Channel, error) {
 	res, err := s.db.Query("SELECT VALUE (SELECT id FROM ->subscribed.out) FROM ONLY $userId;", map[string]string{
 		"userId": "users:" + userId,
@@ -729,6 +2341,30 @@ func (s *service) GetSubscribedChannels(userId string) ([]models.Channel, error)
 	return channels, err
 }
 
+// GetSubscribedServerChannels returns the channels of a server a user is
+// subscribed to, threads included.
+func (s *service) GetSubscribedServerChannels(userId, serverId string) ([]string, error) {
+	res, err := s.db.Query(`
+      SELECT VALUE out FROM subscribed
+      WHERE in = $userId AND (IF out.parent_channel THEN out.parent_channel ELSE out END) IN (SELECT VALUE array::flatten(categories.channels) FROM ONLY $serverId);
+    `, map[string]string{
+		"userId":   userId,
+		"serverId": serverId,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	channels, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return channels, nil
+}
+
 type jcServerReturn struct {
 	Server         models.Server `json:"server"`
 	ServerChannels []string      `json:"server_channels"`
@@ -862,49 +2498,68 @@ func (s *service) CreateServer(userId, name string) (jcServerReturn, error) {
 	return server, nil
 }
 
-func (s *service) DeleteServer(userId, serverId string) error {
+func (s *service) DeleteServer(userId, serverId string) ([]string, error) {
 	res, err := s.db.Query(`SELECT VALUE roles FROM ONLY member WHERE in = $userId AND out = $serverId LIMIT 1;`, map[string]string{
 		"serverId": serverId,
 		"userId":   userId,
 	})
 	if err != nil {
 		log.Println(err)
-		return fmt.Errorf("an error occured while deleting the server")
+		return nil, fmt.Errorf("an error occured while deleting the server")
 	}
 
 	roles, err := surrealdb.SmartUnmarshal[[]string](res, err)
 	if err != nil {
 		log.Println(err)
-		return fmt.Errorf("an error occured while deleting the server")
+		return nil, fmt.Errorf("an error occured while deleting the server")
 	} else if !slices.Contains(roles, "owner") {
-		return fmt.Errorf("an error occured while deleting the server")
+		return nil, fmt.Errorf("an error occured while deleting the server")
 	}
 
-	_, err = s.db.Query(`
+	res, err = s.db.Query(`
       BEGIN TRANSACTION;
       LET $serverChannels = (SELECT VALUE array::flatten(categories.channels) FROM ONLY $serverId);
+      LET $threads = (SELECT VALUE id FROM channels WHERE parent_channel IN $serverChannels);
+      LET $channels = array::concat($serverChannels, $threads);
       DELETE $serverId;
-      DELETE $serverChannels;
-      DELETE messages WHERE channel_id IN $serverChannels;
+      DELETE emojis WHERE server = $serverId;
+      DELETE $channels;
+      DELETE subscribed WHERE out IN $channels;
+      DELETE message_revisions WHERE message_id.channel_id IN $channels;
+      DELETE reactions WHERE message_id.channel_id IN $channels;
+      DELETE messages WHERE channel_id IN $channels;
+      DELETE pins WHERE channel_id IN $channels;
+
+      RETURN $channels;
       COMMIT TRANSACTION;
 	   `, map[string]string{
 		"serverId": serverId,
 	})
 	if err != nil {
 		log.Println(err)
-		return fmt.Errorf("an error occured while deleting the server")
+		return nil, fmt.Errorf("an error occured while deleting the server")
 	}
 
-	return nil
+	channels, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, fmt.Errorf("an error occured while deleting the server")
+	}
+
+	return channels, nil
 }
 
-func (s *service) LeaveServer(userId, serverId string) error {
-	_, err := s.db.Query(`
+func (s *service) LeaveServer(userId, serverId string) ([]string, error) {
+	res, err := s.db.Query(`
       BEGIN TRANSACTION;
       LET $serverChannels = (SELECT VALUE array::flatten(categories.channels) FROM ONLY $serverId);
+      LET $threads = (SELECT VALUE id FROM channels WHERE parent_channel IN $serverChannels);
+      LET $channels = array::concat($serverChannels, $threads);
 
       DELETE member WHERE in=$userId AND out=$serverId;
-      DELETE subscribed WHERE in=$userId AND out IN $serverChannels;
+      DELETE subscribed WHERE in=$userId AND out IN $channels;
+
+      RETURN $channels;
       COMMIT TRANSACTION;
 	   `, map[string]string{
 		"userId":   userId,
@@ -912,10 +2567,16 @@ func (s *service) LeaveServer(userId, serverId string) error {
 	})
 	if err != nil {
 		log.Println(err)
-		return fmt.Errorf("an error occured while leaving the server")
+		return nil, fmt.Errorf("an error occured while leaving the server")
 	}
 
-	return nil
+	channels, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, fmt.Errorf("an error occured while leaving the server")
+	}
+
+	return channels, nil
 }
 
 type createChannelReturn struct {
@@ -962,13 +2623,22 @@ func (s *service) CreateChannel(serverId, categoryName, channelType, channelName
 	return channelAndMembers, nil
 }
 
-func (s *service) RemoveChannel(serverId, categoryName, channelId string) error {
-	_, err := s.db.Query(`
+// RemoveChannel deletes a channel along with its threads, returning the ids
+// of both.
+func (s *service) RemoveChannel(serverId, categoryName, channelId string) ([]string, error) {
+	res, err := s.db.Query(`
       BEGIN TRANSACTION;
-      DELETE $channelId;
+      LET $threads = (SELECT VALUE id FROM channels WHERE parent_channel = $channelId);
+      LET $channels = array::concat([$channelId], $threads);
+      DELETE $channels;
       UPDATE $serverId SET categories[WHERE name=$categoryName][0].channels -= $channelId;
-      DELETE subscribed WHERE out=$channelId;
-      DELETE messages WHERE channel_id=$channelId;
+      DELETE subscribed WHERE out IN $channels;
+      DELETE message_revisions WHERE message_id.channel_id IN $channels;
+      DELETE reactions WHERE message_id.channel_id IN $channels;
+      DELETE messages WHERE channel_id IN $channels;
+      DELETE pins WHERE channel_id IN $channels;
+
+      RETURN $channels;
       COMMIT TRANSACTION;
 	   `, map[string]string{
 		"categoryName": categoryName,
@@ -977,10 +2647,16 @@ func (s *service) RemoveChannel(serverId, categoryName, channelId string) error
 	})
 	if err != nil {
 		log.Println(err)
-		return fmt.Errorf("an error occured while leaving the server")
+		return nil, fmt.Errorf("an error occured while leaving the server")
 	}
 
-	return nil
+	channels, err := surrealdb.SmartUnmarshal[[]string](res, err)
+	if err != nil {
+		log.Println(err)
+		return nil, fmt.Errorf("an error occured while deleting the channel")
+	}
+
+	return channels, nil
 }
 
 func (s *service) CreateCategory(serverId, categoryName string) error {
@@ -1000,11 +2676,17 @@ func (s *service) RemoveCategory(serverId, categoryName string) ([]string, error
 	res, err := s.db.Query(`
       BEGIN TRANSACTION;
       LET $category = SELECT VALUE categories[WHERE name=$categoryName][0] FROM ONLY $serverId;
+      LET $threads = (SELECT VALUE id FROM channels WHERE parent_channel IN $category.channels);
+      LET $channels = array::concat($category.channels, $threads);
       UPDATE $serverId SET categories -= $category;
-      DELETE $category.channels;
-      DELETE messages WHERE channel_id IN $category.channels;
-
-      RETURN $category.channels;
+      DELETE $channels;
+      DELETE subscribed WHERE out IN $channels;
+      DELETE message_revisions WHERE message_id.channel_id IN $channels;
+      DELETE reactions WHERE message_id.channel_id IN $channels;
+      DELETE messages WHERE channel_id IN $channels;
+      DELETE pins WHERE channel_id IN $channels;
+
+      RETURN $channels;
       COMMIT TRANSACTION;
 	   `, map[string]string{
 		"categoryName": categoryName,
@@ -1230,14 +2912,83 @@ func (s *service) CheckInvitationValidity(invitationId string) (models.Invitatio
 }
 
 func (s *service) UpdateUserStatus(userId, status string) error {
-	_, err := s.db.Query(`UPDATE ONLY $userId SET status=$status`, map[string]string{
+	_, err := s.db.Query(`UPDATE ONLY $userId SET status=$status, last_seen=time::now()`, map[string]string{
 		"userId": "users:" + userId,
 		"status": status,
 	})
 	if err != nil {
 		log.Println(err)
-		return fmt.Errorf("an error occured while leaving the server")
+		return fmt.Errorf("an error occured while updating the status")
 	}
 
 	return nil
 }
+
+func (s *service) UpdatePreferredStatus(userId, status string) error {
+	_, err := s.db.Query(`UPDATE ONLY $userId SET preferred_status=$status`, map[string]string{
+		"userId": "users:" + userId,
+		"status": status,
+	})
+	if err != nil {
+		log.Println(err)
+		return fmt.Errorf("an error occured while updating the status")
+	}
+
+	return nil
+}
+
+func (s *service) IsServerMember(userId, serverId string) (bool, error) {
+	res, err := s.db.Query(`RETURN array::len(SELECT id FROM member WHERE in=$userId AND out=$serverId) > 0;`, map[string]string{
+		"userId":   userId,
+		"serverId": serverId,
+	})
+	if err != nil {
+		log.Println(err)
+		return false, fmt.Errorf("an error occured while checking the membership")
+	}
+
+	return surrealdb.SmartUnmarshal[bool](res, err)
+}
+
+func (s *service) IsServerChannel(serverId, channelId string) (bool, error) {
+	res, err := s.db.Query(`RETURN $channelId IN (SELECT VALUE array::flatten(categories.channels) FROM ONLY $serverId);`, map[string]string{
+		"serverId":  serverId,
+		"channelId": channelId,
+	})
+	if err != nil {
+		log.Println(err)
+		return false, fmt.Errorf("an error occured while checking the channel")
+	}
+
+	return surrealdb.SmartUnmarshal[bool](res, err)
+}
+
+// IsChannelMember tells whether a user is subscribed to a channel or, for a
+// thread, to its parent channel.
+func (s *service) IsChannelMember(userId, channelId string) (bool, error) {
+	res, err := s.db.Query(`RETURN array::len(SELECT id FROM subscribed WHERE in=$userId AND out=(IF $channelId.parent_channel THEN $channelId.parent_channel ELSE $channelId END)) > 0;`, map[string]string{
+		"userId":    userId,
+		"channelId": channelId,
+	})
+	if err != nil {
+		log.Println(err)
+		return false, fmt.Errorf("an error occured while checking the membership")
+	}
+
+	return surrealdb.SmartUnmarshal[bool](res, err)
+}
+
+func (s *service) AreFriends(userId, friendId string) (bool, error) {
+	res, err := s.db.Query(`
+      RETURN array::len(SELECT id FROM friends WHERE accepted=true AND ((in=$userId AND out=$friendId) OR (in=$friendId AND out=$userId))) > 0;
+    `, map[string]string{
+		"userId":   userId,
+		"friendId": friendId,
+	})
+	if err != nil {
+		log.Println(err)
+		return false, fmt.Errorf("an error occured while checking the friendship")
+	}
+
+	return surrealdb.SmartUnmarshal[bool](res, err)
+}
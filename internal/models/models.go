@@ -1,17 +1,19 @@
 package models
 
 type User struct {
-	ID            string `json:"id,omitempty"`
-	Email         string `json:"email,omitempty"`
-	Password      string `json:"password,omitempty"`
-	Username      string `json:"username,omitempty"`
-	DisplayName   string `json:"display_name,omitempty"`
-	Avatar        string `json:"avatar,omitempty"`
-	Banner        string `json:"banner,omitempty"`
-	Status        string `json:"status,omitempty"`
-	AboutMe       string `json:"about_me"`
-	UsernameColor string `json:"username_color,omitempty"`
-	CreatedAt     string `json:"created_at,omitempty"`
+	ID              string `json:"id,omitempty"`
+	Email           string `json:"email,omitempty"`
+	Password        string `json:"password,omitempty"`
+	Username        string `json:"username,omitempty"`
+	DisplayName     string `json:"display_name,omitempty"`
+	Avatar          string `json:"avatar,omitempty"`
+	Banner          string `json:"banner,omitempty"`
+	Status          string `json:"status,omitempty"`
+	PreferredStatus string `json:"preferred_status,omitempty"`
+	LastSeen        string `json:"last_seen,omitempty"`
+	AboutMe         string `json:"about_me"`
+	UsernameColor   string `json:"username_color,omitempty"`
+	CreatedAt       string `json:"created_at,omitempty"`
 }
 
 type Session struct {
@@ -23,15 +25,26 @@ type Session struct {
 	UserId     string `json:"user_id"`
 }
 
+type WebsocketTicket struct {
+	ID        string `json:"id,omitempty"`
+	SessionId string `json:"session_id"`
+	UserId    string `json:"user_id"`
+	ExpiresAt string `json:"expires_at"`
+}
+
 type Server struct {
-	ID         string     `json:"id,omitempty"`
-	Name       string     `json:"name"`
-	Icon       string     `json:"icon,omitempty"`
-	Banner     string     `json:"banner,omitempty"`
-	Categories []Category `json:"categories,omitempty"`
-	Roles      []string   `json:"roles,omitempty"`
-	Members    []User     `json:"members"`
-	CreatedAt  string     `json:"created_at,omitempty"`
+	ID          string     `json:"id,omitempty"`
+	Name        string     `json:"name"`
+	Icon        string     `json:"icon,omitempty"`
+	Banner      string     `json:"banner,omitempty"`
+	Categories  []Category `json:"categories,omitempty"`
+	Roles       []string   `json:"roles,omitempty"`
+	Members     []User     `json:"members"`
+	EditHistory string     `json:"edit_history,omitempty"`
+	// MentionableRoles can be mentioned by every member, the others only
+	// by moderators.
+	MentionableRoles []string `json:"mentionable_roles,omitempty"`
+	CreatedAt        string   `json:"created_at,omitempty"`
 }
 
 type Category struct {
@@ -49,16 +62,129 @@ type Channel struct {
 }
 
 type Message struct {
-	ID        string   `json:"id,omitempty"`
-	Author    User     `json:"author"`
-	ChannelId string   `json:"channel_id"`
-	Content   string   `json:"content"`
-	Edited    bool     `json:"edited"`
-	Images    []string `json:"images,omitempty"`
-	Mentions  []string `json:"mentions,omitempty"`
-	Reply     Reply    `json:"replies,omitempty"`
-	UpdatedAt string   `json:"updated_at,omitempty"`
-	CreatedAt string   `json:"created_at,omitempty"`
+	ID              string     `json:"id,omitempty"`
+	Author          User       `json:"author"`
+	ChannelId       string     `json:"channel_id"`
+	Content         string     `json:"content"`
+	Edited          bool       `json:"edited"`
+	Images          []string   `json:"images,omitempty"`
+	Mentions        []string   `json:"mentions,omitempty"`
+	MentionRoles    []string   `json:"mention_roles,omitempty"`
+	MentionChannels []string   `json:"mention_channels,omitempty"`
+	MentionEveryone bool       `json:"mention_everyone,omitempty"`
+	MentionHere     bool       `json:"mention_here,omitempty"`
+	Reply           Reply      `json:"replies,omitempty"`
+	Reactions       []Reaction `json:"reactions,omitempty"`
+	Thread          *Thread    `json:"thread,omitempty"`
+	UpdatedAt       string     `json:"updated_at,omitempty"`
+	CreatedAt       string     `json:"created_at,omitempty"`
+
+	// MentionedUsers are all the users the mentions resolved to when the
+	// message was written, roles, @everyone and @here included.
+	MentionedUsers []string `json:"-"`
+}
+
+// SearchQuery is a parsed message search: the words to look for and the
+// filters narrowing it down.
+type SearchQuery struct {
+	Text     string
+	From     []string
+	In       []string
+	Mentions []string
+	Has      []string
+	Before   string
+	After    string
+	Limit    int
+	Offset   int
+}
+
+// SearchScope is where a search looks: the channels of a server the user is
+// subscribed to, a single channel, or the user's conversations. Threads are
+// searched along with their parent channel.
+type SearchScope struct {
+	ServerId  string
+	ChannelId string
+	DMs       bool
+}
+
+// SearchResult is a message matching a search with the messages around it.
+type SearchResult struct {
+	Message Message   `json:"message"`
+	Before  []Message `json:"before"`
+	After   []Message `json:"after"`
+}
+
+// Revision is a previous version of an edited message, written at
+// WrittenAt and replaced at ReplacedAt.
+type Revision struct {
+	Content    string   `json:"content"`
+	Mentions   []string `json:"mentions"`
+	WrittenAt  string   `json:"written_at"`
+	ReplacedAt string   `json:"replaced_at"`
+}
+
+// Pin is a message pinned in its channel.
+type Pin struct {
+	Message  Message `json:"message"`
+	PinnedBy string  `json:"pinned_by"`
+	PinnedAt string  `json:"pinned_at"`
+}
+
+// Thread is a channel started from a message of another channel. It is
+// archived once it has had no message for AutoArchive minutes.
+type Thread struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	ParentChannelId string `json:"parent_channel_id"`
+	ParentMessageId string `json:"parent_message_id"`
+	OwnerId         string `json:"owner_id"`
+	MessageCount    int    `json:"message_count"`
+	LastMessageAt   string `json:"last_message_at"`
+	Archived        bool   `json:"archived"`
+	AutoArchive     int    `json:"auto_archive"`
+	CreatedAt       string `json:"created_at"`
+}
+
+// Reaction counts the users who reacted to a message with a unicode emoji,
+// or with a custom emoji of the server when EmojiId is set. Me tells whether
+// the user asking for the message is one of them.
+type Reaction struct {
+	Emoji   string `json:"emoji"`
+	EmojiId string `json:"emoji_id,omitempty"`
+	Count   int    `json:"count"`
+	Me      bool   `json:"me"`
+}
+
+// Emoji is a custom emoji of a server, usable in its channels.
+type Emoji struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Image     string `json:"image"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt string `json:"created_at"`
+}
+
+// DMChannel is a conversation between two users, or a group conversation
+// with a name, an icon and an owner. It comes with a preview of its last
+// message and how many messages the user asking for it hasn't read.
+type DMChannel struct {
+	ID            string     `json:"id"`
+	Type          string     `json:"type"`
+	Name          string     `json:"name,omitempty"`
+	Icon          string     `json:"icon,omitempty"`
+	OwnerId       string     `json:"owner_id,omitempty"`
+	Recipients    []User     `json:"recipients"`
+	LastMessage   *DMPreview `json:"last_message,omitempty"`
+	LastMessageAt string     `json:"last_message_at"`
+	UnreadCount   int        `json:"unread_count"`
+	CreatedAt     string     `json:"created_at"`
+}
+
+type DMPreview struct {
+	ID        string `json:"id"`
+	AuthorId  string `json:"author_id"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
 }
 
 type Reply struct {
@@ -78,3 +204,13 @@ type Invitation struct {
 	Initiator   User   `json:"initiator"`
 	NumberOfUse int    `json:"number_of_use"`
 }
+
+// MessagePage selects a page of messages before, after or around a cursor,
+// which is either a message id or an RFC 3339 timestamp. Without cursor the
+// page holds the latest messages.
+type MessagePage struct {
+	Before string
+	After  string
+	Around string
+	Limit  int
+}
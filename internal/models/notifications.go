@@ -21,3 +21,11 @@ type MessageNotif struct {
 	CreatedAt string   `json:"created_at"`
 	Read      bool     `json:"read"`
 }
+
+type ReadState struct {
+	ChannelId     string `json:"channel_id"`
+	LastMessageId string `json:"last_message_id"`
+	LastReadAt    string `json:"last_read_at"`
+	UnreadCount   int    `json:"unread_count"`
+	MentionCount  int    `json:"mention_count"`
+}
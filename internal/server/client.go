@@ -0,0 +1,338 @@
+package server
+
+import (
+	"encoding/binary"
+	"goback/internal/utils"
+	"goback/proto/protoMess"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lxzan/event_emitter"
+	"github.com/lxzan/gws"
+)
+
+const (
+	// ResumeWindow is how long a client outlives its dropped socket, buffering
+	// the events sent meanwhile so a reconnect can pick up where it left off.
+	ResumeWindow = 2 * time.Minute
+
+	// ReplayBufferSize is the number of events a client keeps for resuming.
+	ReplayBufferSize = 512
+)
+
+// CloseResyncRequired is sent when a client can't keep up with its events.
+// It can resume, and resyncs if it fell behind the replay buffer.
+const CloseResyncRequired uint16 = 4009
+
+// What happens when the outbound queue of a socket is full, set with
+// WS_OVERFLOW_POLICY. OverflowDrop drops low priority events and disconnects
+// for anything else, OverflowDisconnect always disconnects.
+const (
+	OverflowDrop       = "drop"
+	OverflowDisconnect = "disconnect"
+)
+
+var (
+	queueSize      = envInt("WS_QUEUE_SIZE", 256)
+	overflowPolicy = os.Getenv("WS_OVERFLOW_POLICY")
+)
+
+// Counters of the outbound queues since startup.
+var (
+	droppedEvents       atomic.Int64
+	overflowDisconnects atomic.Int64
+)
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+
+	return value
+}
+
+// lowPriorityEvents are the event types a slow client can afford to miss.
+// They are neither numbered nor replayed, being stale by the time it resumes.
+var lowPriorityEvents = map[string]bool{
+	"typing": true,
+}
+
+// outgoing is an event waiting to be written, with its sequence number.
+type outgoing struct {
+	seq uint64
+	ev  Event
+}
+
+// Client is the server side of a websocket session. It is what subscribes to
+// topics, numbers every event it sends and keeps the last ones around so a
+// client reconnecting with ?resume=<id>&seq=<n> receives exactly what it
+// missed.
+//
+// Events are written in the Format of the socket. Binary events are prefixed
+// with their sequence number as 8 big-endian bytes and text events get a
+// "seq" field. Sequence 0 is used for events that are
+// not replayed, like hello.
+//
+// Events are queued and written by a goroutine per socket, so a slow socket
+// never holds up whoever publishes them. See Send for when the queue is full.
+type Client struct {
+	ID        string
+	UserId    string
+	ConnId    string
+	SessionId string
+
+	// Intents and Lazy are set once, before the client is subscribed. A lazy
+	// client is only subscribed to the servers it asks for.
+	Intents Intents
+	Lazy    bool
+
+	subscriberId int64
+	md           *gws.ConcurrentMap[string, any]
+
+	mu         sync.Mutex
+	conn       *gws.Conn
+	queue      chan outgoing
+	overflowed bool
+	seq        uint64
+	replay     []Event
+	expiry     *time.Timer
+
+	// servers holds the servers the client explicitly subscribed to or
+	// unsubscribed from, see follows.
+	servers map[string]bool
+}
+
+func NewClient(userId, connId, sessionId string) *Client {
+	id, _ := utils.GenerateRandomId(24)
+
+	return &Client{
+		ID:           id,
+		UserId:       userId,
+		ConnId:       connId,
+		SessionId:    sessionId,
+		subscriberId: rand.Int63(),
+		md:           gws.NewConcurrentMap[string, any](16),
+		replay:       make([]Event, ReplayBufferSize),
+		Intents:      AllIntents,
+		servers:      make(map[string]bool),
+	}
+}
+
+func (c *Client) GetSubscriberID() int64 {
+	return c.subscriberId
+}
+
+func (c *Client) GetMetadata() event_emitter.Metadata {
+	return c.md
+}
+
+// deliver sends an event published to a topic, unless the client has no
+// intent for it. Events sent to the user directly are always delivered.
+func (c *Client) deliver(topic string, ev Event) {
+	if topic != userTopic(c.UserId) && !c.Intents.Has(ev.Type) {
+		return
+	}
+
+	c.Send(ev)
+}
+
+// follows reports whether the client wants the events of a server. Lazy
+// clients only follow the servers they subscribed to, others every server
+// they didn't unsubscribe from.
+func (c *Client) follows(serverId string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	following, ok := c.servers[serverId]
+	if !ok {
+		return !c.Lazy
+	}
+
+	return following
+}
+
+func (c *Client) follow(serverId string, following bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.servers[serverId] = following
+}
+
+// Send numbers an event, keeps it for replay and queues it for the socket if
+// the client is currently connected. When the queue is full, low priority
+// events are dropped, and otherwise the socket is closed with
+// CloseResyncRequired so the client resumes from what it did receive.
+func (c *Client) Send(ev Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lowPriority := lowPriorityEvents[ev.Type]
+	out := outgoing{ev: ev}
+	if !lowPriority {
+		c.seq++
+		c.replay[c.seq%ReplayBufferSize] = ev
+		out.seq = c.seq
+	}
+
+	if c.conn == nil || c.overflowed {
+		return
+	}
+
+	select {
+	case c.queue <- out:
+		return
+	default:
+	}
+
+	if lowPriority && overflowPolicy != OverflowDisconnect {
+		droppedEvents.Add(1)
+		return
+	}
+
+	c.overflowed = true
+	overflowDisconnects.Add(1)
+	closeConn(c.conn, CloseResyncRequired, "too many pending events")
+}
+
+// closeConn writes the close frame of a socket from another goroutine, since
+// it waits behind whatever a slow socket hasn't accepted yet and the client
+// lock must not be held meanwhile.
+func closeConn(conn *gws.Conn, code uint16, reason string) {
+	go func() {
+		_ = conn.SetWriteDeadline(time.Now().Add(time.Second))
+		conn.WriteClose(code, []byte(reason))
+	}()
+}
+
+// QueueDepth returns the number of events waiting to be written.
+func (c *Client) QueueDepth() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.queue)
+}
+
+// writeLoop writes the backlog of a socket, then its queue until it is closed.
+func (c *Client) writeLoop(conn *gws.Conn, format Format, backlog []outgoing, queue <-chan outgoing) {
+	for _, out := range backlog {
+		c.write(conn, format, out)
+	}
+
+	for out := range queue {
+		c.write(conn, format, out)
+	}
+}
+
+func (c *Client) write(conn *gws.Conn, format Format, out outgoing) {
+	op, data := format.Opcode(), out.ev.Encode(format)
+
+	var err error
+	if op == gws.OpcodeText && len(data) > 1 && data[0] == '{' {
+		err = conn.Writev(op, []byte(`{"seq":`+strconv.FormatUint(out.seq, 10)+`,`), data[1:])
+	} else {
+		header := make([]byte, 8)
+		binary.BigEndian.PutUint64(header, out.seq)
+		err = conn.Writev(op, header, data)
+	}
+
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// attach binds the client to a new socket, which receives its events in the
+// given format. It greets the socket with hello and ready, when the client
+// is new, then replays the events after lastSeq, or sends resync_required
+// when some of them are no longer buffered and the client has to refetch
+// its state. Events published between the subscriptions of a new client and
+// its ready are replayed after it, since the snapshot may predate them.
+func (c *Client) attach(conn *gws.Conn, format Format, lastSeq uint64, resumed bool, ready *Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.expiry != nil {
+		c.expiry.Stop()
+		c.expiry = nil
+	}
+
+	if c.conn != nil && c.conn != conn {
+		closeConn(c.conn, 1000, "connection has been resumed")
+		close(c.queue)
+	}
+	c.conn = conn
+	c.queue = make(chan outgoing, queueSize)
+	c.overflowed = false
+
+	go c.writeLoop(conn, format, c.backlog(lastSeq, resumed, ready), c.queue)
+}
+
+// backlog returns what a new socket receives before the queue of the client.
+func (c *Client) backlog(lastSeq uint64, resumed bool, ready *Event) []outgoing {
+	backlog := []outgoing{{ev: c.hello("hello", resumed)}}
+	if ready != nil {
+		backlog = append(backlog, outgoing{ev: *ready})
+	}
+	if lastSeq > c.seq || c.seq-lastSeq > ReplayBufferSize {
+		backlog = append(backlog, outgoing{ev: c.hello("resync_required", resumed)})
+	} else {
+		for seq := lastSeq + 1; seq <= c.seq; seq++ {
+			backlog = append(backlog, outgoing{seq: seq, ev: c.replay[seq%ReplayBufferSize]})
+		}
+	}
+
+	return backlog
+}
+
+func (c *Client) hello(eventType string, resumed bool) Event {
+	wsMess := &protoMess.WSMessage{
+		Type: eventType,
+		Content: &protoMess.WSMessage_Hello{
+			Hello: &protoMess.Hello{
+				SessionId: c.ID,
+				Seq:       c.seq,
+				Resumed:   resumed,
+			},
+		},
+	}
+
+	return NewEvent(wsMess)
+}
+
+// detach unbinds a closed socket and calls expire once the client hasn't
+// been resumed for ResumeWindow. It reports false when the socket had
+// already been replaced.
+func (c *Client) detach(conn *gws.Conn, expire func()) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn != conn {
+		return false
+	}
+
+	close(c.queue)
+	c.conn = nil
+	c.queue = nil
+	c.expiry = time.AfterFunc(ResumeWindow, expire)
+	return true
+}
+
+// close closes the socket of the client, if any, and stops it from being resumed.
+func (c *Client) close(code uint16, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.expiry != nil {
+		c.expiry.Stop()
+		c.expiry = nil
+	}
+
+	if c.conn != nil {
+		closeConn(c.conn, code, reason)
+	}
+}
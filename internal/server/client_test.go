@@ -0,0 +1,154 @@
+package server
+
+import (
+	"goback/proto/protoMess"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lxzan/gws"
+)
+
+func testEvent(eventType string) Event {
+	return NewEvent(&protoMess.WSMessage{Type: eventType})
+}
+
+func numberedEvent(n int) Event {
+	return testEvent(strconv.Itoa(n))
+}
+
+func TestClientBacklog(t *testing.T) {
+	ready := testEvent("ready")
+
+	tests := []struct {
+		name    string
+		sent    int
+		lastSeq uint64
+		ready   *Event
+		want    []string
+	}{
+		{name: "new client", ready: &ready, want: []string{"hello", "ready"}},
+		{name: "events before ready", sent: 2, ready: &ready, want: []string{"hello", "ready", "1", "2"}},
+		{name: "resume", sent: 5, lastSeq: 3, want: []string{"hello", "4", "5"}},
+		{name: "up to date", sent: 3, lastSeq: 3, want: []string{"hello"}},
+		{name: "whole buffer", sent: ReplayBufferSize + 1, lastSeq: 1},
+		{name: "behind the buffer", sent: ReplayBufferSize + 2, lastSeq: 1, want: []string{"hello", "resync_required"}},
+		{name: "ahead of the client", sent: 2, lastSeq: 5, want: []string{"hello", "resync_required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient("alice", "desktop", "session")
+			for n := 1; n <= tt.sent; n++ {
+				client.Send(numberedEvent(n))
+			}
+
+			want := tt.want
+			if want == nil {
+				want = []string{"hello"}
+				for seq := tt.lastSeq + 1; seq <= uint64(tt.sent); seq++ {
+					want = append(want, strconv.FormatUint(seq, 10))
+				}
+			}
+
+			backlog := client.backlog(tt.lastSeq, false, tt.ready)
+			if len(backlog) != len(want) {
+				t.Fatalf("%d events, expected %d", len(backlog), len(want))
+			}
+
+			for i, out := range backlog {
+				if out.ev.Type != want[i] {
+					t.Errorf("event %d is %q, expected %q", i, out.ev.Type, want[i])
+				}
+
+				seq, err := strconv.ParseUint(out.ev.Type, 10, 64)
+				if err != nil {
+					seq = 0
+				}
+				if out.seq != seq {
+					t.Errorf("event %q has seq %d", out.ev.Type, out.seq)
+				}
+			}
+		})
+	}
+}
+
+// serverSocket opens a websocket to a test server and returns its server side.
+func serverSocket(t *testing.T) *gws.Conn {
+	t.Helper()
+
+	sockets := make(chan *gws.Conn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		socket, err := gws.NewUpgrader(&gws.BuiltinEventHandler{}, nil).Upgrade(w, r)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		sockets <- socket
+		go socket.ReadLoop()
+	}))
+	t.Cleanup(srv.Close)
+
+	socket, _, err := gws.NewClient(&gws.BuiltinEventHandler{}, &gws.ClientOption{
+		Addr: "ws" + strings.TrimPrefix(srv.URL, "http"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go socket.ReadLoop()
+	t.Cleanup(func() { socket.NetConn().Close() })
+
+	return <-sockets
+}
+
+func TestClientOverflow(t *testing.T) {
+	tests := []struct {
+		policy     string
+		eventType  string
+		overflowed bool
+		dropped    int64
+	}{
+		{policy: "", eventType: "typing", dropped: 1},
+		{policy: OverflowDrop, eventType: "typing", dropped: 1},
+		{policy: OverflowDrop, eventType: "text_message", overflowed: true},
+		{policy: OverflowDisconnect, eventType: "typing", overflowed: true},
+		{policy: OverflowDisconnect, eventType: "text_message", overflowed: true},
+	}
+
+	defer func(policy string) { overflowPolicy = policy }(overflowPolicy)
+
+	for _, tt := range tests {
+		t.Run(tt.policy+"/"+tt.eventType, func(t *testing.T) {
+			overflowPolicy = tt.policy
+
+			client := NewClient("alice", "desktop", "session")
+			client.conn = serverSocket(t)
+			client.queue = make(chan outgoing, 1)
+
+			dropped, disconnects := droppedEvents.Load(), overflowDisconnects.Load()
+			client.Send(testEvent("text_message"))
+			client.Send(testEvent(tt.eventType))
+
+			if client.overflowed != tt.overflowed {
+				t.Errorf("overflowed = %v", client.overflowed)
+			}
+			if n := droppedEvents.Load() - dropped; n != tt.dropped {
+				t.Errorf("%d events dropped, expected %d", n, tt.dropped)
+			}
+
+			wantDisconnects := int64(0)
+			if tt.overflowed {
+				wantDisconnects = 1
+			}
+			if n := overflowDisconnects.Load() - disconnects; n != wantDisconnects {
+				t.Errorf("%d disconnects, expected %d", n, wantDisconnects)
+			}
+
+			if len(client.queue) != 1 {
+				t.Errorf("%d events queued", len(client.queue))
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"errors"
+	"goback/proto/protoMess"
+	"log"
+	"strings"
+)
+
+var (
+	errInvalidCommand = errors.New("invalid command")
+	errChannelAccess  = errors.New("you don't have access to this channel")
+	errServerAccess   = errors.New("you are not a member of this server")
+)
+
+// handleCommand runs a command a client sent over its websocket, on behalf
+// of the user the socket was authenticated as, and returns its acknowledgement.
+func (s *Server) handleCommand(client *Client, cmd *protoMess.ClientCommand) *protoMess.CommandAck {
+	ack := &protoMess.CommandAck{RequestId: cmd.RequestId}
+	userId := "users:" + client.UserId
+
+	var err error
+	switch p := cmd.Payload.(type) {
+	case *protoMess.ClientCommand_Typing:
+		err = s.commandTyping(userId, p.Typing)
+	case *protoMess.ClientCommand_SendMessage:
+		ack.Message, err = s.commandSendMessage(userId, p.SendMessage)
+	case *protoMess.ClientCommand_MarkRead:
+		err = s.db.UpdateMessageNotifications(userId, p.MarkRead.Channels)
+	case *protoMess.ClientCommand_VoiceState:
+		err = s.commandVoiceState(userId, p.VoiceState)
+	case *protoMess.ClientCommand_Presence:
+		s.ws.presence.SetIdle(client.UserId, client.ID, p.Presence.Idle)
+	case *protoMess.ClientCommand_Servers:
+		err = s.commandServers(client, userId, p.Servers)
+	case *protoMess.ClientCommand_Ack:
+		err = s.commandAck(userId, p.Ack)
+	default:
+		err = errInvalidCommand
+	}
+
+	if err != nil {
+		ack.Error = err.Error()
+	} else {
+		ack.Ok = true
+	}
+
+	return ack
+}
+
+func (s *Server) commandTyping(userId string, cmd *protoMess.TypingCommand) error {
+	if cmd.ChannelId == "" || (cmd.Status != "start" && cmd.Status != "stop") {
+		return errInvalidCommand
+	}
+
+	if cmd.Status == "stop" {
+		s.ws.typing.Stop(userId, cmd.ChannelId)
+		return nil
+	}
+
+	conv := conversationOf(cmd.PrivateMessage, cmd.GroupMessage)
+	if err := s.checkChannelAccess(userId, cmd.ChannelId, conv); err != nil {
+		return err
+	}
+
+	s.ws.typing.Start(userId, cmd.ChannelId, conv)
+
+	return nil
+}
+
+// publishTyping tells a channel, the other user of a DM or the rest of a
+// group that a user started or stopped typing.
+func (s *Server) publishTyping(userId, channelId string, conv conversation, status string) {
+	user, err := s.db.GetUser(userId, "", "")
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	wsMess := &protoMess.WSMessage{
+		Type: "typing",
+		Content: &protoMess.WSMessage_Typing{
+			Typing: &protoMess.Typing{
+				UserId:      userId,
+				DisplayName: user.DisplayName,
+				ChannelId:   channelId,
+				Status:      status,
+			},
+		},
+	}
+
+	switch conv {
+	case privateConversation:
+		s.ws.SendToUser(channelId, wsMess)
+	case groupConversation:
+		users, err := s.db.GetDMUsers("dm_channels:" + channelId)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		for _, user := range users {
+			if user != userId {
+				s.ws.SendToUser(strings.Split(user, ":")[1], wsMess)
+			}
+		}
+	default:
+		s.ws.Publish("channels:"+channelId, wsMess)
+	}
+}
+
+func (s *Server) commandSendMessage(userId string, cmd *protoMess.SendMessageCommand) (*protoMess.Message, error) {
+	if cmd.ChannelId == "" || strings.TrimSpace(cmd.Content) == "" {
+		return nil, errInvalidCommand
+	}
+
+	if err := s.checkChannelAccess(userId, cmd.ChannelId, conversationOf(cmd.PrivateMessage, cmd.GroupMessage)); err != nil {
+		return nil, err
+	}
+
+	author, err := s.db.GetUser(userId, "", "")
+	if err != nil {
+		return nil, err
+	}
+	author.Password = ""
+
+	return s.sendMessage(&CreateMessage{
+		Author:         author,
+		ChannelId:      cmd.ChannelId,
+		Content:        cmd.Content,
+		PrivateMessage: cmd.PrivateMessage,
+		GroupMessage:   cmd.GroupMessage,
+		ServerId:       cmd.ServerId,
+		Reply:          cmd.Reply,
+	}, make([]string, 0))
+}
+
+func (s *Server) commandAck(userId string, cmd *protoMess.AckCommand) error {
+	if cmd.ChannelId == "" || cmd.MessageId == "" {
+		return errInvalidCommand
+	}
+
+	conv := conversationOf(cmd.PrivateMessage, cmd.GroupMessage)
+	if err := s.checkChannelAccess(userId, cmd.ChannelId, conv); err != nil {
+		return err
+	}
+
+	_, err := s.ackChannel(userId, cmd.ChannelId, cmd.MessageId, conv)
+	return err
+}
+
+// commandVoiceState tells a server the user joined, left or changed their
+// state in one of its voice channels. An empty channel means they left.
+func (s *Server) commandVoiceState(userId string, cmd *protoMess.VoiceStateCommand) error {
+	if cmd.ServerId == "" {
+		return errInvalidCommand
+	}
+
+	member, err := s.db.IsServerMember(userId, cmd.ServerId)
+	if err != nil {
+		return err
+	} else if !member {
+		return errServerAccess
+	}
+
+	if cmd.ChannelId != "" {
+		inServer, err := s.db.IsServerChannel(cmd.ServerId, cmd.ChannelId)
+		if err != nil {
+			return err
+		} else if !inServer {
+			return errChannelAccess
+		}
+	}
+
+	user, err := s.db.GetUser(userId, "", "")
+	if err != nil {
+		return err
+	}
+
+	wsMess := &protoMess.WSMessage{
+		Type: "voice_state",
+		Content: &protoMess.WSMessage_VoiceState{
+			VoiceState: &protoMess.ParticipantMove{
+				User: &protoMess.User{
+					Id:            user.ID,
+					Username:      user.Username,
+					DisplayName:   user.DisplayName,
+					Avatar:        user.Avatar,
+					UsernameColor: user.UsernameColor,
+				},
+				UserId:    userId,
+				ServerId:  cmd.ServerId,
+				ChannelId: cmd.ChannelId,
+				Muted:     cmd.Muted,
+				Deafen:    cmd.Deafen,
+			},
+		},
+	}
+
+	s.ws.Publish(cmd.ServerId, wsMess)
+
+	return nil
+}
+
+// commandServers subscribes the client to the servers it opens and
+// unsubscribes it from those it closes, along with their channels.
+func (s *Server) commandServers(client *Client, userId string, cmd *protoMess.ServerSubscriptionCommand) error {
+	for _, serverId := range cmd.Subscribe {
+		member, err := s.db.IsServerMember(userId, serverId)
+		if err != nil {
+			return err
+		} else if !member {
+			return errServerAccess
+		}
+
+		channels, err := s.db.GetSubscribedServerChannels(userId, serverId)
+		if err != nil {
+			return err
+		}
+
+		client.follow(serverId, true)
+		s.ws.Subscribe(serverId, client)
+		for _, channel := range channels {
+			s.ws.Subscribe(channel, client)
+		}
+	}
+
+	for _, serverId := range cmd.Unsubscribe {
+		channels, err := s.db.GetSubscribedServerChannels(userId, serverId)
+		if err != nil {
+			return err
+		}
+
+		client.follow(serverId, false)
+		s.ws.Unsubscribe(serverId, client)
+		for _, channel := range channels {
+			s.ws.Unsubscribe(channel, client)
+		}
+	}
+
+	return nil
+}
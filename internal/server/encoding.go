@@ -0,0 +1,122 @@
+package server
+
+import (
+	"fmt"
+	"goback/internal/utils"
+	"goback/proto/protoMess"
+	"log"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/lxzan/gws"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	EncodingProto = "proto"
+	EncodingJSON  = "json"
+
+	CompressionBrotli  = "brotli"
+	CompressionDeflate = "deflate"
+	CompressionNone    = "none"
+)
+
+// brotliLevel is the quality events are compressed at with brotli, from 0 to
+// 11, set with WS_BROTLI_LEVEL.
+var brotliLevel = envInt("WS_BROTLI_LEVEL", brotli.BestCompression)
+
+// Format is how a socket wants its events, picked at connect time with the
+// encoding and compression query parameters. Events are protobuf compressed
+// with brotli by default.
+type Format struct {
+	Encoding    string
+	Compression string
+}
+
+var DefaultFormat = Format{Encoding: EncodingProto, Compression: CompressionBrotli}
+
+// ParseFormat validates the encoding and compression asked for by a socket,
+// empty values falling back to DefaultFormat.
+func ParseFormat(encoding, compression string) (Format, error) {
+	format := DefaultFormat
+	if encoding != "" {
+		format.Encoding = encoding
+	}
+	if compression != "" {
+		format.Compression = compression
+	}
+
+	if format.Encoding != EncodingProto && format.Encoding != EncodingJSON {
+		return Format{}, fmt.Errorf("unknown encoding %q", format.Encoding)
+	}
+	if format.Compression != CompressionBrotli && format.Compression != CompressionDeflate && format.Compression != CompressionNone {
+		return Format{}, fmt.Errorf("unknown compression %q", format.Compression)
+	}
+
+	return format, nil
+}
+
+// Opcode returns the opcode events are written with. Uncompressed JSON is
+// sent as text so it is readable as is, everything else is binary.
+func (f Format) Opcode() gws.Opcode {
+	if f.Encoding == EncodingJSON && f.Compression == CompressionNone {
+		return gws.OpcodeText
+	}
+
+	return gws.OpcodeBinary
+}
+
+// Event is a message as handed to the clients subscribed to a topic, before
+// it gets its sequence number. It is encoded once per format and the result
+// is shared by every client that wants that format.
+type Event struct {
+	Type    string
+	Message *protoMess.WSMessage
+
+	cache *eventCache
+}
+
+type eventCache struct {
+	mu      sync.Mutex
+	encoded map[Format][]byte
+}
+
+func NewEvent(wsMess *protoMess.WSMessage) Event {
+	return Event{
+		Type:    wsMess.Type,
+		Message: wsMess,
+		cache:   &eventCache{encoded: make(map[Format][]byte)},
+	}
+}
+
+// Encode returns the event encoded and compressed for a format.
+func (e Event) Encode(format Format) []byte {
+	e.cache.mu.Lock()
+	defer e.cache.mu.Unlock()
+
+	if data, ok := e.cache.encoded[format]; ok {
+		return data
+	}
+
+	var data []byte
+	var err error
+	if format.Encoding == EncodingJSON {
+		data, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(e.Message)
+	} else {
+		data, err = proto.Marshal(e.Message)
+	}
+	if err != nil {
+		log.Println(err)
+	}
+
+	switch format.Compression {
+	case CompressionBrotli:
+		data = utils.CompressBrotli(data, brotliLevel)
+	case CompressionDeflate:
+		data = utils.CompressDeflate(data)
+	}
+
+	e.cache.encoded[format] = data
+	return data
+}
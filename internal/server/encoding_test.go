@@ -0,0 +1,101 @@
+package server
+
+import (
+	"bytes"
+	"compress/zlib"
+	"goback/proto/protoMess"
+	"io"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/lxzan/gws"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		encoding    string
+		compression string
+		want        Format
+		wantErr     bool
+	}{
+		{want: DefaultFormat},
+		{encoding: "json", want: Format{Encoding: EncodingJSON, Compression: CompressionBrotli}},
+		{compression: "none", want: Format{Encoding: EncodingProto, Compression: CompressionNone}},
+		{encoding: "json", compression: "deflate", want: Format{Encoding: EncodingJSON, Compression: CompressionDeflate}},
+		{encoding: "xml", wantErr: true},
+		{compression: "gzip", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		format, err := ParseFormat(tt.encoding, tt.compression)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q, %q) error = %v", tt.encoding, tt.compression, err)
+			continue
+		}
+		if format != tt.want {
+			t.Errorf("ParseFormat(%q, %q) = %+v", tt.encoding, tt.compression, format)
+		}
+	}
+}
+
+func TestEventEncode(t *testing.T) {
+	wsMess := &protoMess.WSMessage{
+		Type: "change_status",
+		Content: &protoMess.WSMessage_ChangeStatus{
+			ChangeStatus: &protoMess.ChangeStatus{UserId: "users:alice", Status: "dnd"},
+		},
+	}
+	ev := NewEvent(wsMess)
+
+	for _, encoding := range []string{EncodingProto, EncodingJSON} {
+		for _, compression := range []string{CompressionBrotli, CompressionDeflate, CompressionNone} {
+			format := Format{Encoding: encoding, Compression: compression}
+			t.Run(encoding+"/"+compression, func(t *testing.T) {
+				data := ev.Encode(format)
+				if again := ev.Encode(format); &again[0] != &data[0] {
+					t.Error("the event was encoded twice")
+				}
+
+				var r io.Reader = bytes.NewReader(data)
+				switch compression {
+				case CompressionBrotli:
+					r = brotli.NewReader(r)
+				case CompressionDeflate:
+					zr, err := zlib.NewReader(r)
+					if err != nil {
+						t.Fatal(err)
+					}
+					r = zr
+				}
+				raw, err := io.ReadAll(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				decoded := new(protoMess.WSMessage)
+				if encoding == EncodingJSON {
+					err = protojson.Unmarshal(raw, decoded)
+				} else {
+					err = proto.Unmarshal(raw, decoded)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !proto.Equal(decoded, wsMess) {
+					t.Errorf("decoded %v", decoded)
+				}
+
+				wantOpcode := gws.OpcodeBinary
+				if encoding == EncodingJSON && compression == CompressionNone {
+					wantOpcode = gws.OpcodeText
+				}
+				if format.Opcode() != wantOpcode {
+					t.Errorf("opcode = %v", format.Opcode())
+				}
+			})
+		}
+	}
+}
@@ -285,6 +285,8 @@ func (s *Server) HandlerLogout(c echo.Context) error {
 		return c.JSON(http.StatusUnauthorized, resp)
 	}
 
+	s.ws.CloseSession(sessionUserKey(c), sessionCookie.Value)
+
 	resp["message"] = "success"
 
 	return c.JSON(http.StatusOK, resp)
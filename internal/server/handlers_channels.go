@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"goback/internal/utils"
 	"goback/proto/protoMess"
 	"log"
 	"net/http"
@@ -10,8 +9,6 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/livekit/protocol/livekit"
-	"github.com/lxzan/gws"
-	"google.golang.org/protobuf/proto"
 )
 
 type createChannelBody struct {
@@ -33,10 +30,12 @@ type categoryBody struct {
 }
 
 type typingBody struct {
-	DisplayName string `json:"display_name"`
-	UserId      string `json:"user_id"`
-	ChannelId   string `json:"channel_id"`
-	Status      string `json:"status"`
+	DisplayName    string `json:"display_name"`
+	UserId         string `json:"user_id"`
+	ChannelId      string `json:"channel_id"`
+	Status         string `json:"status"`
+	PrivateMessage bool   `json:"private_message"`
+	GroupMessage   bool   `json:"group_message"`
 }
 
 func (s *Server) HandlerCreateChannel(c echo.Context) error {
@@ -59,9 +58,7 @@ func (s *Server) HandlerCreateChannel(c echo.Context) error {
 	}
 
 	for _, member := range channelAndMembers.Members {
-		if conn, ok := s.ws.sessions.Load(strings.Split(member, ":")[1]); ok {
-			Sub(globalEmitter, channelAndMembers.Channel.ID, &Socket{conn})
-		}
+		s.ws.SubscribeUser(strings.Split(member, ":")[1], body.ServerId, channelAndMembers.Channel.ID)
 	}
 
 	resp["message"] = "success"
@@ -85,14 +82,7 @@ func (s *Server) HandlerCreateChannel(c echo.Context) error {
 		},
 	}
 
-	data, err := proto.Marshal(wsMess)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
-
-	compMess := utils.CompressMess(data)
-	Pub(globalEmitter, body.ServerId, gws.OpcodeBinary, compMess)
+	s.ws.Publish(body.ServerId, wsMess)
 
 	return c.JSON(http.StatusOK, resp)
 }
@@ -109,7 +99,7 @@ func (s *Server) HandlerDeleteChannel(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err := s.db.RemoveChannel(body.ServerId, body.CategoryName, body.ChannelId)
+	channels, err := s.db.RemoveChannel(body.ServerId, body.CategoryName, body.ChannelId)
 	if err != nil {
 		resp["name"] = "unexpected"
 		resp["message"] = "An error occured when deleting the channel."
@@ -133,14 +123,8 @@ func (s *Server) HandlerDeleteChannel(c echo.Context) error {
 		},
 	}
 
-	data, err := proto.Marshal(wsMess)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
-
-	compMess := utils.CompressMess(data)
-	Pub(globalEmitter, body.ServerId, gws.OpcodeBinary, compMess)
+	s.ws.Publish(body.ServerId, wsMess)
+	s.ws.Drop(channels...)
 
 	return c.JSON(http.StatusOK, resp)
 }
@@ -175,14 +159,7 @@ func (s *Server) HandlerCreateCategory(c echo.Context) error {
 		},
 	}
 
-	data, err := proto.Marshal(wsMess)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
-
-	compMess := utils.CompressMess(data)
-	Pub(globalEmitter, body.ServerId, gws.OpcodeBinary, compMess)
+	s.ws.Publish(body.ServerId, wsMess)
 
 	return c.JSON(http.StatusOK, resp)
 }
@@ -217,14 +194,8 @@ func (s *Server) HandlerDeleteCategory(c echo.Context) error {
 		},
 	}
 
-	data, err := proto.Marshal(wsMess)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
-
-	compMess := utils.CompressMess(data)
-	Pub(globalEmitter, body.ServerId, gws.OpcodeBinary, compMess)
+	s.ws.Publish(body.ServerId, wsMess)
+	s.ws.Drop(channels...)
 
 	res, _ := s.rtc.ListRooms(context.Background(), &livekit.ListRoomsRequest{
 		Names: channels,
@@ -248,33 +219,27 @@ func (s *Server) HandlerTyping(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	resp["message"] = "success"
-
-	wsMess := &protoMess.WSMessage{
-		Type: "typing",
-		Content: &protoMess.WSMessage_Typing{
-			Typing: &protoMess.Typing{
-				UserId:      body.UserId,
-				DisplayName: body.DisplayName,
-				ChannelId:   body.ChannelId,
-				Status:      body.Status,
-			},
-		},
-	}
-
-	data, err := proto.Marshal(wsMess)
+	userId, err := sessionUserId(c, body.UserId)
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 
-	compMess := utils.CompressMess(data)
+	if body.ChannelId == "" || (body.Status != "start" && body.Status != "stop") {
+		resp["message"] = "An error occured on typing indicator."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
 
-	if connFriend, ok := s.ws.sessions.Load(body.ChannelId); ok {
-		connFriend.WriteMessage(gws.OpcodeBinary, compMess)
+	conv := conversationOf(body.PrivateMessage, body.GroupMessage)
+	if body.Status == "stop" {
+		s.ws.typing.Stop(userId, body.ChannelId)
+	} else if err := s.checkChannelAccess(userId, body.ChannelId, conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
 	} else {
-		Pub(globalEmitter, "channels:"+body.ChannelId, gws.OpcodeBinary, compMess)
+		s.ws.typing.Start(userId, body.ChannelId, conv)
 	}
 
+	resp["message"] = "success"
+
 	return c.JSON(http.StatusOK, resp)
 }
@@ -0,0 +1,462 @@
+package server
+
+import (
+	"bytes"
+	"goback/internal/database"
+	"goback/internal/models"
+	"goback/internal/utils"
+	"goback/proto/protoMess"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/labstack/echo/v4"
+)
+
+// conversation tells what the bare channel id of a request is: a server
+// channel, the other user of a private conversation or a group conversation.
+type conversation int
+
+const (
+	serverChannel conversation = iota
+	privateConversation
+	groupConversation
+)
+
+func conversationOf(privateMessage, groupMessage bool) conversation {
+	switch {
+	case groupMessage:
+		return groupConversation
+	case privateMessage:
+		return privateConversation
+	}
+
+	return serverChannel
+}
+
+// channel returns the full id of the channel holding the messages of a user
+// in a conversation.
+func (conv conversation) channel(userId, channelId string) string {
+	switch conv {
+	case privateConversation:
+		return database.DMChannelId(userId, "users:"+channelId)
+	case groupConversation:
+		return "dm_channels:" + channelId
+	}
+
+	return "channels:" + channelId
+}
+
+type createGroupDMBody struct {
+	Name  string   `json:"name"`
+	Users []string `json:"users"`
+}
+
+type groupDMUserBody struct {
+	DMId   string `json:"dm_id"`
+	UserId string `json:"user_id"`
+}
+
+type updateGroupDMBody struct {
+	DMId string `json:"dm_id"`
+	Name string `json:"name"`
+}
+
+// HandlerDMChannels lists the conversations of the user, the most recently
+// active first.
+func (s *Server) HandlerDMChannels(c echo.Context) error {
+	resp := make(map[string]any)
+
+	sess := c.Get("session").(models.Session)
+
+	dms, err := s.db.GetDMChannels(sess.UserId)
+	if err != nil {
+		resp["message"] = "An error occured when getting your conversations."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	resp["dms"] = dms
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerGroupDMMessages returns a page of the messages of a group the user
+// is in, see messagePage.
+func (s *Server) HandlerGroupDMMessages(c echo.Context) error {
+	resp := make(map[string]any)
+
+	dmId := c.Param("dmId")
+	if err := s.checkChannelAccess(sessionUser(c).ID, dmId, groupConversation); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	page, err := messagePage(c)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	messages, hasMore, err := s.db.GetChannelMessages(sessionUser(c).ID, "dm_channels:"+dmId, page)
+	if err != nil {
+		resp["message"] = err
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	resp["messages"] = messages
+	resp["has_more"] = hasMore
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerCreateGroupDM opens a group conversation between the user, who owns
+// it, and some of their friends.
+func (s *Server) HandlerCreateGroupDM(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(createGroupDMBody)
+	if err := c.Bind(body); err != nil || len(body.Users) == 0 {
+		resp["message"] = "A group needs at least one other user."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	ownerId := sessionUser(c).ID
+	var userIds []string
+	for _, userId := range body.Users {
+		userId = "users:" + strings.TrimPrefix(userId, "users:")
+		if userId == ownerId || slices.Contains(userIds, userId) {
+			continue
+		}
+
+		friends, err := s.db.AreFriends(ownerId, userId)
+		if err != nil || !friends {
+			resp["message"] = "You can only add your friends to a group."
+			return c.JSON(http.StatusForbidden, resp)
+		}
+		userIds = append(userIds, userId)
+	}
+
+	if len(userIds) == 0 || len(userIds)+1 > database.MaxGroupDMUsers {
+		resp["message"] = "A group holds from 2 to " + strconv.Itoa(database.MaxGroupDMUsers) + " users."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	bareIds := make([]string, 0, len(userIds))
+	for _, userId := range userIds {
+		bareIds = append(bareIds, strings.Split(userId, ":")[1])
+	}
+
+	dmId, err := s.db.CreateGroupDM(ownerId, strings.TrimSpace(body.Name), bareIds)
+	if err != nil {
+		resp["message"] = "An error occured when creating the group."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	dm, err := s.db.GetDMChannel(dmId)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	s.sendDMChannel("dm_create", dm, append(userIds, ownerId))
+
+	resp["dm"] = dm
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerAddGroupDMUser adds one of the user's friends to a group they are
+// in.
+func (s *Server) HandlerAddGroupDMUser(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(groupDMUserBody)
+	if err := c.Bind(body); err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	dm, err := s.groupDM(userId, body.DMId)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	newUserId := "users:" + strings.TrimPrefix(body.UserId, "users:")
+	if dmHasUser(dm, newUserId) {
+		resp["message"] = "This user is already in the group."
+		return c.JSON(http.StatusBadRequest, resp)
+	} else if len(dm.Recipients) >= database.MaxGroupDMUsers {
+		resp["message"] = "A group holds at most " + strconv.Itoa(database.MaxGroupDMUsers) + " users."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	friends, err := s.db.AreFriends(userId, newUserId)
+	if err != nil || !friends {
+		resp["message"] = "You can only add your friends to a group."
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	if err := s.db.AddDMUser(dm.ID, newUserId); err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	dm, err = s.db.GetDMChannel(dm.ID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	var userIds []string
+	for _, user := range dm.Recipients {
+		if user.ID != newUserId {
+			userIds = append(userIds, user.ID)
+		}
+	}
+	s.sendDMChannel("dm_create", dm, []string{newUserId})
+	s.sendDMChannel("dm_update", dm, userIds)
+
+	resp["dm"] = dm
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerRemoveGroupDMUser makes the user leave a group, or kicks someone
+// else out of it when they own it.
+func (s *Server) HandlerRemoveGroupDMUser(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(groupDMUserBody)
+	if err := c.Bind(body); err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	dm, err := s.groupDM(userId, body.DMId)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	removedId := userId
+	if body.UserId != "" {
+		removedId = "users:" + strings.TrimPrefix(body.UserId, "users:")
+	}
+	if removedId != userId && dm.OwnerId != userId {
+		resp["message"] = "Only the owner of the group can remove someone from it."
+		return c.JSON(http.StatusForbidden, resp)
+	} else if !dmHasUser(dm, removedId) {
+		resp["message"] = "This user isn't in the group."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if err := s.db.RemoveDMUser(dm.ID, removedId); err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	s.ws.SendToUser(strings.Split(removedId, ":")[1], &protoMess.WSMessage{
+		Type: "dm_delete",
+		Content: &protoMess.WSMessage_DmChannel{
+			DmChannel: &protoMess.DMChannel{
+				Id:   dm.ID,
+				Type: dm.Type,
+			},
+		},
+	})
+
+	if len(dm.Recipients) > 1 {
+		dm, err = s.db.GetDMChannel(dm.ID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resp)
+		}
+
+		var userIds []string
+		for _, user := range dm.Recipients {
+			userIds = append(userIds, user.ID)
+		}
+		s.sendDMChannel("dm_update", dm, userIds)
+	}
+
+	resp["message"] = "success"
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerUpdateGroupDM renames a group, which any of its users can do.
+func (s *Server) HandlerUpdateGroupDM(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(updateGroupDMBody)
+	if err := c.Bind(body); err != nil || strings.TrimSpace(body.Name) == "" {
+		resp["message"] = "The name of a group can't be empty."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	dm, err := s.groupDM(sessionUser(c).ID, body.DMId)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	if err := s.db.UpdateGroupDM(dm.ID, strings.TrimSpace(body.Name), ""); err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	dm.Name = strings.TrimSpace(body.Name)
+	s.broadcastDMUpdate(dm)
+
+	resp["dm"] = dm
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerChangeGroupDMIcon crops and uploads the icon of a group, which any
+// of its users can change.
+func (s *Server) HandlerChangeGroupDMIcon(c echo.Context) error {
+	resp := make(map[string]any)
+
+	cropX, _ := strconv.Atoi(c.FormValue("cropX"))
+	cropY, _ := strconv.Atoi(c.FormValue("cropY"))
+	cropWidth, _ := strconv.Atoi(c.FormValue("cropWidth"))
+	cropHeight, _ := strconv.Atoi(c.FormValue("cropHeight"))
+
+	dm, err := s.groupDM(sessionUser(c).ID, c.FormValue("dm_id"))
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	file, err := c.FormFile("icon")
+	if err != nil {
+		log.Println(err)
+		return c.String(http.StatusInternalServerError, "Failed to get file")
+	}
+
+	if file.Size > 8*1024*1024 {
+		resp["message"] = "File size exceeds 8MB limit"
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		log.Println(err)
+		return c.String(http.StatusInternalServerError, "Failed to open file")
+	}
+	defer src.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, src); err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to read image")
+	}
+
+	image, ext, err := cropImage(buf.Bytes(), cropX, cropY, cropWidth, cropHeight)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	randId, _ := utils.GenerateRandomId(6)
+	iconKey := strings.Split(dm.ID, ":")[1] + "-icon-" + randId + ext
+	_, err = s.s3.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String("Hudori"),
+		Key:    aws.String(iconKey),
+		Body:   bytes.NewReader(image),
+	})
+	if err != nil {
+		log.Println(err)
+		return c.String(http.StatusInternalServerError, "Failed to upload image")
+	}
+
+	if err := s.db.UpdateGroupDM(dm.ID, "", iconKey); err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to update link")
+	}
+
+	dm.Icon = iconKey
+	s.broadcastDMUpdate(dm)
+
+	resp["icon"] = iconKey
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// groupDM returns a group conversation given by its bare id, if the user is
+// in it.
+func (s *Server) groupDM(userId, dmId string) (models.DMChannel, error) {
+	if dmId == "" {
+		return models.DMChannel{}, errChannelAccess
+	}
+
+	dm, err := s.db.GetDMChannel("dm_channels:" + strings.TrimPrefix(dmId, "dm_channels:"))
+	if err != nil || dm.Type != "group" || !dmHasUser(dm, userId) {
+		return models.DMChannel{}, errChannelAccess
+	}
+
+	return dm, nil
+}
+
+func dmHasUser(dm models.DMChannel, userId string) bool {
+	return slices.ContainsFunc(dm.Recipients, func(user models.User) bool {
+		return user.ID == userId
+	})
+}
+
+func (s *Server) broadcastDMUpdate(dm models.DMChannel) {
+	var userIds []string
+	for _, user := range dm.Recipients {
+		userIds = append(userIds, user.ID)
+	}
+	s.sendDMChannel("dm_update", dm, userIds)
+}
+
+// sendDMChannel sends a conversation to some of its users, each of them
+// getting the others as recipients.
+func (s *Server) sendDMChannel(eventType string, dm models.DMChannel, userIds []string) {
+	for _, userId := range userIds {
+		channel := &protoMess.DMChannel{
+			Id:            dm.ID,
+			Type:          dm.Type,
+			Name:          dm.Name,
+			Icon:          dm.Icon,
+			OwnerId:       dm.OwnerId,
+			LastMessageAt: dm.LastMessageAt,
+			CreatedAt:     dm.CreatedAt,
+		}
+		for _, user := range dm.Recipients {
+			if user.ID != userId {
+				channel.Recipients = append(channel.Recipients, protoUser(user))
+			}
+		}
+
+		s.ws.SendToUser(strings.Split(userId, ":")[1], &protoMess.WSMessage{
+			Type: eventType,
+			Content: &protoMess.WSMessage_DmChannel{
+				DmChannel: channel,
+			},
+		})
+	}
+}
+
+// sendToConversation delivers an event to everyone in a conversation: the
+// subscribers of a server channel, both users of a private conversation or
+// every user of a group.
+func (s *Server) sendToConversation(userId, channelId string, conv conversation, wsMess *protoMess.WSMessage) {
+	switch conv {
+	case privateConversation:
+		s.ws.SendToUser(strings.Split(userId, ":")[1], wsMess)
+		s.ws.SendToUser(channelId, wsMess)
+	case groupConversation:
+		users, err := s.db.GetDMUsers("dm_channels:" + channelId)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		for _, user := range users {
+			s.ws.SendToUser(strings.Split(user, ":")[1], wsMess)
+		}
+	default:
+		s.ws.Publish("channels:"+channelId, wsMess)
+	}
+}
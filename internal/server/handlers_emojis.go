@@ -0,0 +1,180 @@
+package server
+
+import (
+	"bytes"
+	"goback/internal/database"
+	"goback/internal/models"
+	"goback/internal/utils"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/labstack/echo/v4"
+)
+
+// MaxEmojiSize is the largest image a custom emoji can have.
+const MaxEmojiSize = 256 * 1024
+
+var emojiName = regexp.MustCompile(`^[A-Za-z0-9_]{2,32}$`)
+
+var emojiExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type deleteEmojiBody struct {
+	ServerId string `json:"server_id"`
+	EmojiId  string `json:"emoji_id"`
+}
+
+// HandlerServerEmojis lists the custom emojis of a server.
+func (s *Server) HandlerServerEmojis(c echo.Context) error {
+	resp := make(map[string]any)
+
+	serverId := c.Param("serverId")
+	member, err := s.db.IsServerMember(sessionUser(c).ID, serverId)
+	if err != nil || !member {
+		resp["message"] = errServerAccess.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	emojis, err := s.db.GetServerEmojis(serverId)
+	if err != nil {
+		resp["message"] = "An error occured when getting the emojis."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	resp["emojis"] = emojis
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerCreateEmoji lets the admins of a server add a custom emoji from an
+// uploaded image.
+func (s *Server) HandlerCreateEmoji(c echo.Context) error {
+	resp := make(map[string]any)
+
+	serverId := c.FormValue("server_id")
+	name := c.FormValue("name")
+	if !emojiName.MatchString(name) {
+		resp["message"] = "The name of an emoji is made of 2 to 32 letters, digits and _."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	roles, err := s.db.GetMemberRoles(userId, serverId)
+	if err != nil || !hasAnyRole(roles, adminRoles) {
+		resp["message"] = "You are not allowed to change the settings of this server."
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	// Checked before uploading the image so a refused emoji doesn't leave
+	// it behind, CreateEmoji still checking them for concurrent requests.
+	emojis, err := s.db.GetServerEmojis(serverId)
+	if err != nil {
+		resp["message"] = "An error occured when getting the emojis."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if len(emojis) >= database.MaxServerEmojis {
+		resp["message"] = database.ErrTooManyEmojis.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if slices.ContainsFunc(emojis, func(emoji models.Emoji) bool { return emoji.Name == name }) {
+		resp["message"] = "This server already has an emoji named " + name + "."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		log.Println(err)
+		return c.String(http.StatusInternalServerError, "Failed to get file")
+	}
+
+	if file.Size > MaxEmojiSize {
+		resp["message"] = "File size exceeds 256KB limit"
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		log.Println(err)
+		return c.String(http.StatusInternalServerError, "Failed to open file")
+	}
+	defer src.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, src); err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to read image")
+	}
+
+	ext, ok := emojiExtensions[http.DetectContentType(buf.Bytes())]
+	if !ok {
+		resp["message"] = "An emoji must be a PNG, JPEG, GIF or WebP image."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	randId, _ := utils.GenerateRandomId(6)
+	imageKey := strings.Split(serverId, ":")[1] + "-emoji-" + randId + ext
+	_, err = s.s3.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String("Hudori"),
+		Key:    aws.String(imageKey),
+		Body:   bytes.NewReader(buf.Bytes()),
+	})
+	if err != nil {
+		log.Println(err)
+		return c.String(http.StatusInternalServerError, "Failed to upload image")
+	}
+
+	emoji, err := s.db.CreateEmoji(serverId, userId, name, imageKey)
+	if err != nil {
+		_, delErr := s.s3.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String("Hudori"),
+			Key:    aws.String(imageKey),
+		})
+		if delErr != nil {
+			log.Println(delErr)
+		}
+
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	resp["emoji"] = emoji
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerDeleteEmoji lets the admins of a server remove one of its custom
+// emojis, along with the reactions made with it.
+func (s *Server) HandlerDeleteEmoji(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(deleteEmojiBody)
+	if err := c.Bind(body); err != nil || body.ServerId == "" || body.EmojiId == "" {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	roles, err := s.db.GetMemberRoles(sessionUser(c).ID, body.ServerId)
+	if err != nil || !hasAnyRole(roles, adminRoles) {
+		resp["message"] = "You are not allowed to change the settings of this server."
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	if err := s.db.DeleteEmoji(body.ServerId, strings.TrimPrefix(body.EmojiId, "emojis:")); err != nil {
+		resp["message"] = "An error occured when deleting the emoji."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	resp["message"] = "success"
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -1,22 +1,17 @@
 package server
 
 import (
-	"fmt"
-	"goback/internal/utils"
 	"goback/proto/protoMess"
 	"log"
 	"net/http"
 	"strings"
 
 	"github.com/labstack/echo/v4"
-	"github.com/lxzan/gws"
-	"google.golang.org/protobuf/proto"
 )
 
 type addFriendBody struct {
-	InitiatorId       string `json:"initiator_id"`
-	InitiatorUsername string `json:"initiator_username"`
-	ReceiverUsername  string `json:"receiver_username"`
+	InitiatorId      string `json:"initiator_id"`
+	ReceiverUsername string `json:"receiver_username"`
 }
 
 type acceptFriendBody struct {
@@ -32,7 +27,10 @@ type removeFriendBody struct {
 func (s *Server) HandlerFriends(c echo.Context) error {
 	resp := make(map[string]any)
 
-	userId := fmt.Sprintf("users:%s", c.Param("userId"))
+	userId, err := sessionUserId(c, c.Param("userId"))
+	if err != nil {
+		return err
+	}
 
 	friends, err := s.db.GetFriends(userId)
 	if err != nil {
@@ -56,7 +54,12 @@ func (s *Server) HandlerAddFriend(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	notif, err := s.db.RelateFriends(body.InitiatorId, body.InitiatorUsername, body.ReceiverUsername)
+	initiatorId, err := sessionUserId(c, body.InitiatorId)
+	if err != nil {
+		return err
+	}
+
+	notif, err := s.db.RelateFriends(initiatorId, sessionUser(c).Username, body.ReceiverUsername)
 	if err != nil {
 		log.Println("error when relating users:", err)
 		resp["name"] = "unexpected"
@@ -65,32 +68,23 @@ func (s *Server) HandlerAddFriend(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	if conn, ok := s.ws.sessions.Load(strings.Split(notif.UserId, ":")[1]); ok {
-		mess := &protoMess.WSMessage{
-			Type: "friend_request",
-			Content: &protoMess.WSMessage_FriendRequest{
-				FriendRequest: &protoMess.FriendRequest{
-					Id:          notif.ID,
-					InitiatorId: notif.InitiatorId,
-					RequestId:   notif.RequestId,
-					Message:     notif.Message,
-					Type:        notif.Type,
-					UserId:      notif.UserId,
-					CreatedAt:   notif.CreatedAt,
-				},
+	mess := &protoMess.WSMessage{
+		Type: "friend_request",
+		Content: &protoMess.WSMessage_FriendRequest{
+			FriendRequest: &protoMess.FriendRequest{
+				Id:          notif.ID,
+				InitiatorId: notif.InitiatorId,
+				RequestId:   notif.RequestId,
+				Message:     notif.Message,
+				Type:        notif.Type,
+				UserId:      notif.UserId,
+				CreatedAt:   notif.CreatedAt,
 			},
-		}
-
-		data, err := proto.Marshal(mess)
-		if err != nil {
-			log.Println(err)
-			return err
-		}
-
-		compMess := utils.CompressMess(data)
-		conn.WriteMessage(gws.OpcodeBinary, compMess)
+		},
 	}
 
+	s.ws.SendToUser(strings.Split(notif.UserId, ":")[1], mess)
+
 	resp["message"] = "success"
 
 	return c.JSON(http.StatusOK, resp)
@@ -107,7 +101,12 @@ func (s *Server) HandlerAcceptFriend(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	users, err := s.db.AcceptFriend(body.RequestId, body.NotifId)
+	userId, err := sessionUserId(c)
+	if err != nil {
+		return err
+	}
+
+	users, err := s.db.AcceptFriend(userId, body.RequestId, body.NotifId)
 	if err != nil {
 		log.Println("error when accepting friend request", err)
 		resp["message"] = "An error occured when accepting friend request."
@@ -115,30 +114,21 @@ func (s *Server) HandlerAcceptFriend(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	if conn, ok := s.ws.sessions.Load(strings.Split(users[0].ID, ":")[1]); ok {
-		mess := &protoMess.WSMessage{
-			Type: "friend_accept",
-			Content: &protoMess.WSMessage_FriendAccept{
-				FriendAccept: &protoMess.User{
-					Id:          users[1].ID,
-					DisplayName: users[1].DisplayName,
-					Avatar:      users[1].Avatar,
-					AboutMe:     users[1].AboutMe,
-					Status:      users[1].Status,
-				},
+	mess := &protoMess.WSMessage{
+		Type: "friend_accept",
+		Content: &protoMess.WSMessage_FriendAccept{
+			FriendAccept: &protoMess.User{
+				Id:          users[1].ID,
+				DisplayName: users[1].DisplayName,
+				Avatar:      users[1].Avatar,
+				AboutMe:     users[1].AboutMe,
+				Status:      users[1].Status,
 			},
-		}
-
-		data, err := proto.Marshal(mess)
-		if err != nil {
-			log.Println(err)
-			return err
-		}
-
-		compMess := utils.CompressMess(data)
-		conn.WriteMessage(gws.OpcodeBinary, compMess)
+		},
 	}
 
+	s.ws.SendToUser(strings.Split(users[0].ID, ":")[1], mess)
+
 	resp["message"] = "success"
 	resp["friend"] = users[0]
 
@@ -156,7 +146,12 @@ func (s *Server) HandlerRefuseFriend(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err := s.db.RefuseFriend(body.RequestId, body.NotifId)
+	userId, err := sessionUserId(c)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.RefuseFriend(userId, body.RequestId, body.NotifId)
 	if err != nil {
 		log.Println("error when refusing friend request", err)
 		resp["message"] = "An error occured when refusing friend request."
@@ -180,7 +175,12 @@ func (s *Server) HandlerRemoveFriend(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err := s.db.RemoveFriend(body.UserId, body.FriendId)
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.RemoveFriend(userId, body.FriendId)
 	if err != nil {
 		log.Println("error when refusing friend request", err)
 		resp["message"] = "An error occured when removing your friend."
@@ -188,24 +188,15 @@ func (s *Server) HandlerRemoveFriend(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	if conn, ok := s.ws.sessions.Load(strings.Split(body.FriendId, ":")[1]); ok {
-		mess := &protoMess.WSMessage{
-			Type: "friend_remove",
-			Content: &protoMess.WSMessage_UserId{
-				UserId: body.UserId,
-			},
-		}
-
-		data, err := proto.Marshal(mess)
-		if err != nil {
-			log.Println(err)
-			return err
-		}
-
-		compMess := utils.CompressMess(data)
-		conn.WriteMessage(gws.OpcodeBinary, compMess)
+	mess := &protoMess.WSMessage{
+		Type: "friend_remove",
+		Content: &protoMess.WSMessage_UserId{
+			UserId: userId,
+		},
 	}
 
+	s.ws.SendToUser(strings.Split(body.FriendId, ":")[1], mess)
+
 	resp["message"] = "success"
 
 	return c.JSON(http.StatusOK, resp)
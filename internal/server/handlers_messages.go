@@ -10,6 +10,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -17,8 +18,6 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/labstack/echo/v4"
-	"github.com/lxzan/gws"
-	"google.golang.org/protobuf/proto"
 )
 
 type CreateMessage struct {
@@ -26,40 +25,50 @@ type CreateMessage struct {
 	ChannelId      string      `json:"channel_id"`
 	Content        string      `json:"content"`
 	PrivateMessage bool        `json:"private_message"`
+	GroupMessage   bool        `json:"group_message"`
 	ServerId       string      `json:"server_id,omitempty"`
 	Reply          string      `json:"reply,omitempty"`
-	Mentions       []string    `json:"mentions,omitempty"`
 }
 
 type EditMessage struct {
-	ChannelId      string   `json:"channel_id"`
-	Content        string   `json:"content"`
-	MessageId      string   `json:"message_id,omitempty"`
-	AuthorId       string   `json:"author_id"`
-	Mentions       []string `json:"mentions,omitempty"`
-	PrivateMessage bool     `json:"private_message"`
+	ChannelId      string `json:"channel_id"`
+	Content        string `json:"content"`
+	MessageId      string `json:"message_id,omitempty"`
+	AuthorId       string `json:"author_id"`
+	PrivateMessage bool   `json:"private_message"`
+	GroupMessage   bool   `json:"group_message"`
 }
 
 type DeleteMessage struct {
 	MessageId      string `json:"message_id,omitempty"`
 	ChannelId      string `json:"channel_id"`
-	AuthorId       string `json:"author_id"`
 	PrivateMessage bool   `json:"private_message"`
+	GroupMessage   bool   `json:"group_message"`
 }
 
 func (s *Server) HandlerPrivateMessages(c echo.Context) error {
 	resp := make(map[string]any)
 
 	channelId := c.Param("channelId")
-	userId := fmt.Sprintf("users:%s", c.Param("userId"))
+	userId, err := sessionUserId(c, c.Param("userId"))
+	if err != nil {
+		return err
+	}
+
+	page, err := messagePage(c)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
 
-	messages, err := s.db.GetPrivateMessages(userId, channelId)
+	messages, hasMore, err := s.db.GetPrivateMessages(userId, channelId, page)
 	if err != nil {
 		resp["error"] = err
 		return c.JSON(http.StatusNotFound, resp)
 	}
 
 	resp["messages"] = messages
+	resp["has_more"] = hasMore
 
 	return c.JSON(http.StatusOK, resp)
 }
@@ -67,21 +76,67 @@ func (s *Server) HandlerPrivateMessages(c echo.Context) error {
 func (s *Server) HandlerChannelMessages(c echo.Context) error {
 	resp := make(map[string]any)
 
+	userId := sessionUser(c).ID
 	channelId := c.Param("channelId")
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	before, _ := strconv.Atoi(c.QueryParam("before"))
+	if err := s.checkChannelAccess(userId, channelId, serverChannel); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
 
-	messages, err := s.db.GetChannelMessages(channelId, limit, before)
+	page, err := messagePage(c)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	messages, hasMore, err := s.db.GetChannelMessages(userId, "channels:"+channelId, page)
 	if err != nil {
 		resp["message"] = err
 		return c.JSON(http.StatusNotFound, resp)
 	}
 
 	resp["messages"] = messages
+	resp["has_more"] = hasMore
 
 	return c.JSON(http.StatusOK, resp)
 }
 
+const (
+	DefaultMessagesLimit = 50
+	MaxMessagesLimit     = 100
+)
+
+// messagePage reads the before, after or around cursor of a request for
+// messages, only one of which can be given, and its limit.
+func messagePage(c echo.Context) (models.MessagePage, error) {
+	page := models.MessagePage{
+		Before: c.QueryParam("before"),
+		After:  c.QueryParam("after"),
+		Around: c.QueryParam("around"),
+		Limit:  DefaultMessagesLimit,
+	}
+
+	cursors := 0
+	for _, cursor := range []string{page.Before, page.After, page.Around} {
+		if cursor != "" {
+			cursors++
+		}
+	}
+	if cursors > 1 {
+		return page, fmt.Errorf("only one of before, after and around can be used")
+	}
+
+	if limit := c.QueryParam("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return page, fmt.Errorf("the limit must be a positive number")
+		}
+		page.Limit = min(n, MaxMessagesLimit)
+	}
+
+	return page, nil
+}
+
 func (s *Server) HandlerSendMessage(c echo.Context) error {
 	resp := make(map[string]any)
 
@@ -92,14 +147,18 @@ func (s *Server) HandlerSendMessage(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
+	if _, err := sessionUserId(c, body.Author.ID); err != nil {
+		return err
+	}
+	body.Author = sessionUser(c)
+
+	if err := s.checkChannelAccess(body.Author.ID, body.ChannelId, conversationOf(body.PrivateMessage, body.GroupMessage)); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
 	message := models.Message{
-		Author:    body.Author,
-		ChannelId: body.ChannelId,
-		Content:   body.Content,
-		Reply:     models.Reply{ID: body.Reply},
-		Edited:    false,
-		Images:    make([]string, 0),
-		Mentions:  make([]string, 0),
+		Images: make([]string, 0),
 	}
 
 	form, err := c.MultipartForm()
@@ -147,15 +206,64 @@ func (s *Server) HandlerSendMessage(c echo.Context) error {
 		wg.Wait()
 	}
 
-	message.Mentions = append(message.Mentions, body.Mentions...)
+	_, err = s.sendMessage(body, message.Images)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	return nil
+}
+
+// sendMessage stores a message written by body.Author and delivers it to the
+// channel, to both users of a private conversation or to a group.
+func (s *Server) sendMessage(body *CreateMessage, images []string) (*protoMess.Message, error) {
+	conv := conversationOf(body.PrivateMessage, body.GroupMessage)
+	channelId := conv.channel(body.Author.ID, body.ChannelId)
+	if conv == privateConversation {
+		_, created, err := s.db.EnsureDMChannel(body.Author.ID, "users:"+body.ChannelId)
+		if err != nil {
+			log.Println("error when opening a dm channel", err)
+			return nil, err
+		}
+		if created {
+			dm, err := s.db.GetDMChannel(channelId)
+			if err != nil {
+				return nil, err
+			}
+			s.sendDMChannel("dm_create", dm, []string{body.Author.ID, "users:" + body.ChannelId})
+		}
+	}
+
+	mentions, err := s.resolveMentions(body.Author.ID, body.ChannelId, conv, body.Content)
+	if err != nil {
+		log.Println("error when resolving mentions", err)
+		return nil, err
+	}
+
+	message := models.Message{
+		Author:    body.Author,
+		ChannelId: channelId,
+		Content:   body.Content,
+		Reply:     models.Reply{ID: body.Reply},
+		Edited:    false,
+		Images:    images,
+	}
+	mentions.apply(&message)
+	message.MentionedUsers = s.mentionedUsers(body.ChannelId, mentions)
+
 	mess, err := s.db.CreateMessage(message)
 	if err != nil {
 		log.Println("error when creating a message", err)
-
-		return c.JSON(http.StatusBadRequest, resp)
+		return nil, err
 	}
 
-	go s.SendMessageNotifications(body.PrivateMessage, body.Author.ID, body.ChannelId, body.ServerId, body.Mentions)
+	go s.SendMessageNotifications(conv, body.Author.ID, body.ChannelId, body.ServerId, message.MentionedUsers)
+	if conv != serverChannel {
+		go s.db.TouchDMChannel(channelId, mess.ID)
+	} else {
+		go s.touchThread(channelId, body.Author.ID)
+	}
+	go s.ws.typing.Stop(body.Author.ID, body.ChannelId)
 
 	authorObj := &protoMess.User{
 		Id:            mess.Author.ID,
@@ -165,14 +273,18 @@ func (s *Server) HandlerSendMessage(c echo.Context) error {
 	}
 
 	messObj := &protoMess.Message{
-		Id:        mess.ID,
-		Author:    authorObj,
-		ChannelId: mess.ChannelId,
-		Content:   mess.Content,
-		Images:    mess.Images,
-		Mentions:  mess.Mentions,
-		UpdatedAt: mess.UpdatedAt,
-		CreatedAt: mess.CreatedAt,
+		Id:              mess.ID,
+		Author:          authorObj,
+		ChannelId:       mess.ChannelId,
+		Content:         mess.Content,
+		Images:          mess.Images,
+		Mentions:        mess.Mentions,
+		MentionRoles:    mess.MentionRoles,
+		MentionChannels: mess.MentionChannels,
+		MentionEveryone: mess.MentionEveryone,
+		MentionHere:     mess.MentionHere,
+		UpdatedAt:       mess.UpdatedAt,
+		CreatedAt:       mess.CreatedAt,
 	}
 	if mess.Reply.ID != "" {
 		messObj.Replies = &protoMess.Reply{
@@ -191,30 +303,63 @@ func (s *Server) HandlerSendMessage(c echo.Context) error {
 		},
 	}
 
-	data, err := proto.Marshal(wsMess)
+	s.sendToConversation(body.Author.ID, body.ChannelId, conv, wsMess)
+
+	return messObj, nil
+}
+
+// checkChannelAccess makes sure a user can write in a channel, to the friend
+// whose id is used as channel for private messages or in a group.
+func (s *Server) checkChannelAccess(userId, channelId string, conv conversation) error {
+	var allowed bool
+	var err error
+	switch conv {
+	case privateConversation:
+		allowed, err = s.db.AreFriends(userId, "users:"+channelId)
+	case groupConversation:
+		var users []string
+		users, err = s.db.GetDMUsers("dm_channels:" + channelId)
+		allowed = slices.Contains(users, userId)
+	default:
+		allowed, err = s.db.IsChannelMember(userId, "channels:"+channelId)
+	}
+
 	if err != nil {
-		log.Println(err)
 		return err
+	} else if !allowed {
+		return errChannelAccess
 	}
 
-	compMess := utils.CompressMess(data)
+	return nil
+}
 
-	if body.PrivateMessage {
-		if conn, ok := s.ws.sessions.Load(strings.Split(body.Author.ID, ":")[1]); ok {
-			conn.WriteMessage(gws.OpcodeBinary, compMess)
-		}
-		if connFriend, ok := s.ws.sessions.Load(body.ChannelId); ok {
-			connFriend.WriteMessage(gws.OpcodeBinary, compMess)
+func (s *Server) SendMessageNotifications(conv conversation, authorId, channelId, serverId string, mentions []string) {
+	switch conv {
+	case groupConversation:
+		dmId := "dm_channels:" + channelId
+		users, err := s.db.CreateDMNotifications(dmId, authorId)
+		if err != nil {
+			log.Println(err)
 		}
-	} else {
-		Pub(globalEmitter, "channels:"+body.ChannelId, gws.OpcodeBinary, compMess)
-	}
 
-	return nil
-}
+		for _, u := range users {
+			id, _ := utils.GenerateRandomId(10)
+			wsMess := &protoMess.WSMessage{
+				Type: "new_notification",
+				Content: &protoMess.WSMessage_Notification{
+					Notification: &protoMess.MessageNotif{
+						Id:        id,
+						Type:      "new_message",
+						UserId:    u,
+						ChannelId: dmId,
+						Read:      false,
+					},
+				},
+			}
 
-func (s *Server) SendMessageNotifications(privateMessage bool, authorId, channelId, serverId string, mentions []string) {
-	if privateMessage {
+			s.ws.SendToUser(strings.Split(u, ":")[1], wsMess)
+		}
+	case privateConversation:
 		notif, err := s.db.CreateMessageNotification("users:"+channelId, "users:"+strings.Split(authorId, ":")[1])
 		if err != nil {
 			log.Println(err)
@@ -233,21 +378,9 @@ func (s *Server) SendMessageNotifications(privateMessage bool, authorId, channel
 				},
 			},
 		}
-		data, err := proto.Marshal(wsMess)
-		if err != nil {
-			log.Println(err)
-		}
-
-		compMess := utils.CompressMess(data)
-		if conn, ok := s.ws.sessions.Load(strings.Split(authorId, ":")[1]); ok {
-			conn.WriteMessage(gws.OpcodeBinary, compMess)
-		}
-
-		connFriend, ok := s.ws.sessions.Load(channelId)
-		if ok {
-			connFriend.WriteMessage(gws.OpcodeBinary, compMess)
-		}
-	} else {
+		s.ws.SendToUser(strings.Split(authorId, ":")[1], wsMess)
+		s.ws.SendToUser(channelId, wsMess)
+	default:
 		users, err := s.db.CreateMessageNotifications(channelId, serverId, authorId, mentions)
 		if err != nil {
 			log.Println("error when creating a message", err)
@@ -270,15 +403,7 @@ func (s *Server) SendMessageNotifications(privateMessage bool, authorId, channel
 				},
 			}
 
-			data, err := proto.Marshal(wsMess)
-			if err != nil {
-				log.Println(err)
-			}
-
-			compMess := utils.CompressMess(data)
-			if conn, ok := s.ws.sessions.Load(strings.Split(u, ":")[1]); ok {
-				conn.WriteMessage(gws.OpcodeBinary, compMess)
-			}
+			s.ws.SendToUser(strings.Split(u, ":")[1], wsMess)
 		}
 	}
 }
@@ -292,72 +417,80 @@ func (s *Server) HandlerEditMessage(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err := s.db.EditMessage(body.MessageId, body.Content, body.Mentions)
+	authorId, err := sessionUserId(c, body.AuthorId)
 	if err != nil {
-		log.Println("error when editing a message", err)
+		return err
+	}
+	body.AuthorId = authorId
+
+	conv := conversationOf(body.PrivateMessage, body.GroupMessage)
+	if err := s.checkChannelAccess(authorId, body.ChannelId, conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	messageId := "messages:" + strings.TrimPrefix(body.MessageId, "messages:")
+	writer, err := s.db.GetMessageAuthor(messageId, conv.channel(authorId, body.ChannelId))
+	if err != nil || writer == "" {
+		resp["message"] = "This message doesn't exist."
+		return c.JSON(http.StatusNotFound, resp)
+	} else if writer != authorId {
+		resp["message"] = "Only the author of a message can edit it."
+		return c.JSON(http.StatusForbidden, resp)
+	}
 
+	mentions, err := s.resolveMentions(authorId, body.ChannelId, conv, body.Content)
+	if err != nil {
+		log.Println("error when resolving mentions", err)
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	if body.PrivateMessage {
-		author := &protoMess.User{
-			Id: body.AuthorId,
-		}
+	message := models.Message{
+		ID:      messageId,
+		Content: body.Content,
+	}
+	mentions.apply(&message)
+	message.MentionedUsers = s.mentionedUsers(body.ChannelId, mentions)
 
-		messObj := &protoMess.Message{
-			Id:        body.MessageId,
-			ChannelId: body.ChannelId,
-			Content:   body.Content,
-			Mentions:  body.Mentions,
-			Edited:    true,
-			Author:    author,
-		}
+	err = s.db.EditMessage(message)
+	if err != nil {
+		log.Println("error when editing a message", err)
 
-		wsMess := &protoMess.WSMessage{
-			Type: "edit_message",
-			Content: &protoMess.WSMessage_Mess{
-				Mess: messObj,
-			},
-		}
-		data, err := proto.Marshal(wsMess)
-		if err != nil {
-			log.Println(err)
-			return err
-		}
+		return c.JSON(http.StatusBadRequest, resp)
+	}
 
-		compMess := utils.CompressMess(data)
-		if connFriend, ok := s.ws.sessions.Load(body.ChannelId); ok {
-			connFriend.WriteMessage(gws.OpcodeBinary, compMess)
-		}
-	} else {
-		messObj := &protoMess.Message{
-			Id:        body.MessageId,
-			ChannelId: body.ChannelId,
-			Content:   body.Content,
-			Mentions:  body.Mentions,
-			Edited:    true,
-		}
+	messObj := &protoMess.Message{
+		Id:              body.MessageId,
+		ChannelId:       body.ChannelId,
+		Content:         body.Content,
+		Mentions:        message.Mentions,
+		MentionRoles:    message.MentionRoles,
+		MentionChannels: message.MentionChannels,
+		MentionEveryone: message.MentionEveryone,
+		MentionHere:     message.MentionHere,
+		Edited:          true,
+	}
 
-		wsMess := &protoMess.WSMessage{
-			Type: "edit_message",
-			Content: &protoMess.WSMessage_Mess{
-				Mess: messObj,
-			},
-		}
-		data, err := proto.Marshal(wsMess)
-		if err != nil {
-			log.Println(err)
-			return err
+	if conv != serverChannel {
+		messObj.Author = &protoMess.User{
+			Id: body.AuthorId,
 		}
+	}
 
-		compMess := utils.CompressMess(data)
-		Pub(globalEmitter, "channels:"+body.ChannelId, gws.OpcodeBinary, compMess)
+	wsMess := &protoMess.WSMessage{
+		Type: "edit_message",
+		Content: &protoMess.WSMessage_Mess{
+			Mess: messObj,
+		},
 	}
+	s.sendToConversation(body.AuthorId, body.ChannelId, conv, wsMess)
 
 	resp["message"] = "success"
 	return c.JSON(http.StatusOK, resp)
 }
 
+// HandlerDeleteMessage deletes a message for its author or, in servers, for
+// the moderators of the server.
 func (s *Server) HandlerDeleteMessage(c echo.Context) error {
 	resp := make(map[string]any)
 
@@ -366,61 +499,113 @@ func (s *Server) HandlerDeleteMessage(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err := s.db.DeleteMessage(body.MessageId)
+	userId := sessionUser(c).ID
+	conv := conversationOf(body.PrivateMessage, body.GroupMessage)
+	if err := s.checkChannelAccess(userId, body.ChannelId, conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	messageId := "messages:" + strings.TrimPrefix(body.MessageId, "messages:")
+	authorId, err := s.db.GetMessageAuthor(messageId, conv.channel(userId, body.ChannelId))
+	if err != nil || authorId == "" {
+		resp["message"] = "This message doesn't exist."
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	if authorId != userId && !s.isChannelModerator(userId, body.ChannelId, conv) {
+		resp["message"] = "Only the author of a message or a moderator can delete it."
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	pinned, err := s.db.DeleteMessage(messageId)
 	if err != nil {
 		log.Println("error when deleting a message", err)
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	if body.PrivateMessage {
-		author := &protoMess.User{
-			Id: body.AuthorId,
-		}
+	messObj := &protoMess.Message{
+		Id:        body.MessageId,
+		ChannelId: body.ChannelId,
+		DeletedBy: userId,
+	}
 
-		messObj := &protoMess.Message{
-			Id:        body.MessageId,
-			ChannelId: body.ChannelId,
-			Author:    author,
+	if conv != serverChannel {
+		messObj.Author = &protoMess.User{
+			Id: authorId,
 		}
+	}
 
-		wsMess := &protoMess.WSMessage{
-			Type: "delete_message",
-			Content: &protoMess.WSMessage_Mess{
-				Mess: messObj,
-			},
-		}
-		data, err := proto.Marshal(wsMess)
-		if err != nil {
-			log.Println(err)
-			return err
-		}
+	wsMess := &protoMess.WSMessage{
+		Type: "delete_message",
+		Content: &protoMess.WSMessage_Mess{
+			Mess: messObj,
+		},
+	}
+	s.sendToConversation(userId, body.ChannelId, conv, wsMess)
 
-		compMess := utils.CompressMess(data)
-		if connFriend, ok := s.ws.sessions.Load(body.ChannelId); ok {
-			connFriend.WriteMessage(gws.OpcodeBinary, compMess)
-		}
-	} else {
-		messObj := &protoMess.Message{
-			Id:        body.MessageId,
-			ChannelId: body.ChannelId,
-		}
+	if pinned {
+		s.sendPinsUpdate(userId, body.ChannelId, conv, messageId, false)
+	}
 
-		wsMess := &protoMess.WSMessage{
-			Type: "delete_message",
-			Content: &protoMess.WSMessage_Mess{
-				Mess: messObj,
-			},
-		}
-		data, err := proto.Marshal(wsMess)
+	resp["message"] = "success"
+	return c.JSON(http.StatusOK, resp)
+}
+
+// isChannelModerator reports whether the user moderates the server of a
+// channel. Conversations outside servers have no moderators.
+func (s *Server) isChannelModerator(userId, channelId string, conv conversation) bool {
+	if conv != serverChannel {
+		return false
+	}
+
+	server, err := s.db.GetChannelServer("channels:" + channelId)
+	if err != nil {
+		return false
+	}
+
+	roles, err := s.db.GetMemberRoles(userId, server.ID)
+	return err == nil && hasAnyRole(roles, moderatorRoles)
+}
+
+// HandlerMessageHistory returns the previous versions of a message, the
+// oldest first. In servers that keep their edit history to moderators,
+// other members can't see it.
+func (s *Server) HandlerMessageHistory(c echo.Context) error {
+	resp := make(map[string]any)
+
+	userId := sessionUser(c).ID
+	channelId := c.Param("channelId")
+	conv := conversationOf(c.QueryParam("private_message") == "true", c.QueryParam("group_message") == "true")
+	if err := s.checkChannelAccess(userId, channelId, conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	if conv == serverChannel {
+		server, err := s.db.GetChannelServer("channels:" + channelId)
 		if err != nil {
-			log.Println(err)
-			return err
+			resp["message"] = "An error occured when getting the edit history."
+			return c.JSON(http.StatusBadRequest, resp)
+		}
+
+		if server.EditHistory == "moderators" {
+			roles, err := s.db.GetMemberRoles(userId, server.ID)
+			if err != nil || !hasAnyRole(roles, moderatorRoles) {
+				resp["message"] = "Only moderators can see the edit history in this server."
+				return c.JSON(http.StatusForbidden, resp)
+			}
 		}
+	}
 
-		compMess := utils.CompressMess(data)
-		Pub(globalEmitter, "channels:"+body.ChannelId, gws.OpcodeBinary, compMess)
+	messageId := "messages:" + strings.TrimPrefix(c.Param("messageId"), "messages:")
+	revisions, err := s.db.GetMessageRevisions(messageId, conv.channel(userId, channelId))
+	if err != nil {
+		resp["message"] = "An error occured when getting the edit history."
+		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	resp["message"] = "success"
+	resp["revisions"] = revisions
+
 	return c.JSON(http.StatusOK, resp)
 }
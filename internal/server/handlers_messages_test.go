@@ -0,0 +1,40 @@
+package server
+
+import (
+	"goback/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestMessagePage(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    models.MessagePage
+		wantErr bool
+	}{
+		{query: "", want: models.MessagePage{Limit: DefaultMessagesLimit}},
+		{query: "before=messages:a&limit=20", want: models.MessagePage{Before: "messages:a", Limit: 20}},
+		{query: "after=messages:a", want: models.MessagePage{After: "messages:a", Limit: DefaultMessagesLimit}},
+		{query: "around=messages:a&limit=1000", want: models.MessagePage{Around: "messages:a", Limit: MaxMessagesLimit}},
+		{query: "before=messages:a&after=messages:b", wantErr: true},
+		{query: "after=messages:a&around=messages:b", wantErr: true},
+		{query: "limit=0", wantErr: true},
+		{query: "limit=ten", wantErr: true},
+	}
+
+	e := echo.New()
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+		page, err := messagePage(e.NewContext(req, httptest.NewRecorder()))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("messagePage(%q) error = %v", tt.query, err)
+			continue
+		}
+		if !tt.wantErr && page != tt.want {
+			t.Errorf("messagePage(%q) = %+v", tt.query, page)
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package server
 
 import (
-	"fmt"
+	"errors"
+	"goback/internal/database"
+	"goback/internal/models"
+	"goback/proto/protoMess"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
@@ -13,10 +17,20 @@ type updateNotifications struct {
 	Channels []string `json:"channels"`
 }
 
+type ackBody struct {
+	ChannelId      string `json:"channel_id"`
+	MessageId      string `json:"message_id"`
+	PrivateMessage bool   `json:"private_message"`
+	GroupMessage   bool   `json:"group_message"`
+}
+
 func (s *Server) HandlerNotifications(c echo.Context) error {
 	resp := make(map[string]any)
 
-	userId := fmt.Sprintf("users:%s", c.Param("userId"))
+	userId, err := sessionUserId(c, c.Param("userId"))
+	if err != nil {
+		return err
+	}
 
 	notifications, err := s.db.GetNotifications(userId)
 	if err != nil {
@@ -36,7 +50,12 @@ func (s *Server) HandlerUpdateNotifications(c echo.Context) error {
 		return err
 	}
 
-	err := s.db.UpdateMessageNotifications(body.UserId, body.Channels)
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.UpdateMessageNotifications(userId, body.Channels)
 	if err != nil {
 		log.Println(err)
 		return err
@@ -44,3 +63,67 @@ func (s *Server) HandlerUpdateNotifications(c echo.Context) error {
 
 	return nil
 }
+
+func (s *Server) HandlerAck(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(ackBody)
+	if err := c.Bind(body); err != nil || body.ChannelId == "" || body.MessageId == "" {
+		log.Println(err)
+		resp["message"] = "An error occured when marking the channel as read."
+
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	conv := conversationOf(body.PrivateMessage, body.GroupMessage)
+	if err := s.checkChannelAccess(userId, body.ChannelId, conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	state, err := s.ackChannel(userId, body.ChannelId, body.MessageId, conv)
+	if errors.Is(err, database.ErrMessageNotFound) {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusNotFound, resp)
+	} else if err != nil {
+		resp["message"] = "An error occured when marking the channel as read."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	resp["read_state"] = state
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ackChannel moves the read state of a user in a channel and tells all
+// their devices, so they clear their badges together.
+func (s *Server) ackChannel(userId, channelId, messageId string, conv conversation) (models.ReadState, error) {
+	// private messages are notified with their author as channel
+	notifChannel := conv.channel(userId, channelId)
+	if conv == privateConversation {
+		notifChannel = "users:" + channelId
+	}
+
+	messageId = "messages:" + strings.TrimPrefix(messageId, "messages:")
+	state, err := s.db.AckChannel(userId, conv.channel(userId, channelId), notifChannel, messageId)
+	if err != nil {
+		return models.ReadState{}, err
+	}
+
+	wsMess := &protoMess.WSMessage{
+		Type: "read_state",
+		Content: &protoMess.WSMessage_ReadState{
+			ReadState: &protoMess.ReadState{
+				ChannelId:     state.ChannelId,
+				LastMessageId: state.LastMessageId,
+				UnreadCount:   int32(state.UnreadCount),
+				MentionCount:  int32(state.MentionCount),
+			},
+		},
+	}
+
+	s.ws.SendToUser(strings.Split(userId, ":")[1], wsMess)
+
+	return state, nil
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"goback/proto/protoMess"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type pinBody struct {
+	MessageId      string `json:"message_id"`
+	ChannelId      string `json:"channel_id"`
+	PrivateMessage bool   `json:"private_message"`
+	GroupMessage   bool   `json:"group_message"`
+}
+
+func (s *Server) HandlerPinMessage(c echo.Context) error {
+	return s.handlePin(c, true)
+}
+
+func (s *Server) HandlerUnpinMessage(c echo.Context) error {
+	return s.handlePin(c, false)
+}
+
+// handlePin pins or unpins a message and tells the conversation, unless
+// nothing changed.
+func (s *Server) handlePin(c echo.Context, pin bool) error {
+	resp := make(map[string]any)
+
+	body := new(pinBody)
+	if err := c.Bind(body); err != nil || body.MessageId == "" || body.ChannelId == "" {
+		resp["message"] = "An error occured when pinning the message."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	conv := conversationOf(body.PrivateMessage, body.GroupMessage)
+	if err := s.checkChannelAccess(userId, body.ChannelId, conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	channelId := conv.channel(userId, body.ChannelId)
+	messageId := "messages:" + strings.TrimPrefix(body.MessageId, "messages:")
+
+	var changed bool
+	var err error
+	if pin {
+		changed, err = s.db.PinMessage(channelId, messageId, userId)
+	} else {
+		changed, err = s.db.UnpinMessage(channelId, messageId)
+	}
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if changed {
+		s.sendPinsUpdate(userId, body.ChannelId, conv, messageId, pin)
+	}
+
+	resp["message"] = "success"
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// sendPinsUpdate tells a conversation that a message was pinned or unpinned
+// by a user, deleting a pinned message unpinning it.
+func (s *Server) sendPinsUpdate(userId, channelId string, conv conversation, messageId string, pinned bool) {
+	s.sendToConversation(userId, channelId, conv, &protoMess.WSMessage{
+		Type: "pins_update",
+		Content: &protoMess.WSMessage_PinsUpdate{
+			PinsUpdate: &protoMess.PinsUpdate{
+				ChannelId: channelId,
+				MessageId: messageId,
+				Pinned:    pinned,
+				UserId:    userId,
+			},
+		},
+	})
+}
+
+// HandlerPins lists the pinned messages of a channel, or of a conversation
+// with ?private_message=true or ?group_message=true.
+func (s *Server) HandlerPins(c echo.Context) error {
+	resp := make(map[string]any)
+
+	userId := sessionUser(c).ID
+	channelId := c.Param("channelId")
+	conv := conversationOf(c.QueryParam("private_message") == "true", c.QueryParam("group_message") == "true")
+	if err := s.checkChannelAccess(userId, channelId, conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	pins, err := s.db.GetPins(userId, conv.channel(userId, channelId))
+	if err != nil {
+		resp["message"] = "An error occured when getting the pinned messages."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	resp["pins"] = pins
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"fmt"
+	"goback/proto/protoMess"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	DefaultReactionUsersLimit = 25
+	MaxReactionUsersLimit     = 100
+)
+
+var customEmojiId = regexp.MustCompile(`^[A-Za-z0-9_]{1,32}$`)
+
+type reactionBody struct {
+	MessageId      string `json:"message_id"`
+	ChannelId      string `json:"channel_id"`
+	Emoji          string `json:"emoji"`
+	EmojiId        string `json:"emoji_id"`
+	PrivateMessage bool   `json:"private_message"`
+	GroupMessage   bool   `json:"group_message"`
+}
+
+func (s *Server) HandlerAddReaction(c echo.Context) error {
+	return s.handleReaction(c, "reaction_add")
+}
+
+func (s *Server) HandlerRemoveReaction(c echo.Context) error {
+	return s.handleReaction(c, "reaction_remove")
+}
+
+// handleReaction adds or removes the reaction of the user to a message and
+// tells the conversation, unless nothing changed.
+func (s *Server) handleReaction(c echo.Context, eventType string) error {
+	resp := make(map[string]any)
+
+	body := new(reactionBody)
+	if err := c.Bind(body); err != nil || body.MessageId == "" || body.ChannelId == "" {
+		resp["message"] = "An error occured when reacting to the message."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	conv := conversationOf(body.PrivateMessage, body.GroupMessage)
+	if err := validateEmoji(body.Emoji, body.EmojiId, conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	if err := s.checkChannelAccess(userId, body.ChannelId, conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	if eventType == "reaction_add" && body.EmojiId != "" {
+		if err := s.checkServerEmoji(body.ChannelId, body.EmojiId); err != nil {
+			resp["message"] = err.Error()
+			return c.JSON(http.StatusBadRequest, resp)
+		}
+	}
+
+	messageId := "messages:" + strings.TrimPrefix(body.MessageId, "messages:")
+	channelId := conv.channel(userId, body.ChannelId)
+
+	var changed bool
+	var err error
+	if eventType == "reaction_add" {
+		changed, err = s.db.AddReaction(messageId, channelId, userId, body.Emoji, body.EmojiId)
+	} else {
+		changed, err = s.db.RemoveReaction(messageId, channelId, userId, body.Emoji, body.EmojiId)
+	}
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	if changed {
+		s.sendToConversation(userId, body.ChannelId, conv, &protoMess.WSMessage{
+			Type: eventType,
+			Content: &protoMess.WSMessage_Reaction{
+				Reaction: &protoMess.MessageReaction{
+					MessageId: messageId,
+					ChannelId: body.ChannelId,
+					UserId:    userId,
+					Emoji:     body.Emoji,
+					EmojiId:   body.EmojiId,
+				},
+			},
+		})
+	}
+
+	resp["message"] = "success"
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerReactionUsers lists who reacted to a message with the emoji, or
+// custom emoji_id, given as query parameter.
+func (s *Server) HandlerReactionUsers(c echo.Context) error {
+	resp := make(map[string]any)
+
+	emoji, emojiId := c.QueryParam("emoji"), c.QueryParam("emoji_id")
+	conv := conversationOf(c.QueryParam("private_message") == "true", c.QueryParam("group_message") == "true")
+	if err := validateEmoji(emoji, emojiId, conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	if err := s.checkChannelAccess(userId, c.Param("channelId"), conv); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	limit := DefaultReactionUsersLimit
+	if l := c.QueryParam("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n <= 0 {
+			resp["message"] = "the limit must be a positive number"
+			return c.JSON(http.StatusBadRequest, resp)
+		}
+		limit = min(n, MaxReactionUsersLimit)
+	}
+
+	messageId := "messages:" + strings.TrimPrefix(c.Param("messageId"), "messages:")
+	users, err := s.db.GetReactionUsers(messageId, conv.channel(userId, c.Param("channelId")), emoji, emojiId, limit)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	resp["users"] = users
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// checkServerEmoji makes sure a custom emoji belongs to the server of a
+// channel.
+func (s *Server) checkServerEmoji(channelId, emojiId string) error {
+	server, err := s.db.GetChannelServer("channels:" + channelId)
+	if err != nil {
+		return err
+	}
+
+	found, err := s.db.IsServerEmoji(server.ID, emojiId)
+	if err != nil {
+		return err
+	} else if !found {
+		return fmt.Errorf("this emoji doesn't belong to the server")
+	}
+
+	return nil
+}
+
+// validateEmoji checks that a reaction is either a unicode emoji or the id of
+// a custom emoji, which only exist in servers.
+func validateEmoji(emoji, emojiId string, conv conversation) error {
+	if (emoji == "") == (emojiId == "") {
+		return fmt.Errorf("a reaction needs either an emoji or an emoji_id")
+	}
+
+	if emojiId != "" {
+		if conv != serverChannel {
+			return fmt.Errorf("custom emojis can only be used in servers")
+		} else if !customEmojiId.MatchString(emojiId) {
+			return fmt.Errorf("invalid emoji_id")
+		}
+		return nil
+	}
+
+	if len(emoji) > 32 || !utf8.ValidString(emoji) {
+		return fmt.Errorf("invalid emoji")
+	}
+
+	ascii := true
+	for _, r := range emoji {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return fmt.Errorf("invalid emoji")
+		}
+		if r > unicode.MaxASCII {
+			ascii = false
+		}
+	}
+	if ascii {
+		return fmt.Errorf("invalid emoji")
+	}
+
+	return nil
+}
@@ -14,7 +14,10 @@ func (s *Server) HandlerGenerateRTCToken(c echo.Context) error {
 	resp := make(map[string]any)
 
 	room := c.Param("room")
-	identity := c.Param("identity")
+	identity, err := sessionUserId(c, c.Param("identity"))
+	if err != nil {
+		return err
+	}
 
 	apiKey := os.Getenv("LIVEKIT_KEY")
 	apiSecret := os.Getenv("LIVEKIT_SECRET")
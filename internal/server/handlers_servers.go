@@ -10,7 +10,6 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
@@ -18,11 +17,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/h2non/bimg"
 	"github.com/labstack/echo/v4"
 	"github.com/livekit/protocol/livekit"
-	"github.com/lxzan/gws"
-	"google.golang.org/protobuf/proto"
 )
 
 type JoinServerBody struct {
@@ -40,6 +36,17 @@ type GeneralServerBody struct {
 	ServerId string `json:"server_id"`
 }
 
+type EditHistoryBody struct {
+	ServerId    string `json:"server_id"`
+	EditHistory string `json:"edit_history"`
+}
+
+type MentionableRoleBody struct {
+	ServerId    string `json:"server_id"`
+	Role        string `json:"role"`
+	Mentionable bool   `json:"mentionable"`
+}
+
 // Servers
 func (s *Server) HandlerUsersIdFromChannel(c echo.Context) error {
 	resp := make(map[string]any)
@@ -60,7 +67,10 @@ func (s *Server) HandlerUsersIdFromChannel(c echo.Context) error {
 func (s *Server) HandlerUserServers(c echo.Context) error {
 	resp := make(map[string]any)
 
-	userId := fmt.Sprintf("users:%s", c.Param("userId"))
+	userId, err := sessionUserId(c, c.Param("userId"))
+	if err != nil {
+		return err
+	}
 
 	servers, err := s.db.GetUserServers(userId)
 	if err != nil {
@@ -107,7 +117,10 @@ func (s *Server) getParticipants(channel *models.Channel) ([]models.User, error)
 func (s *Server) HandlerServerInformations(c echo.Context) error {
 	resp := make(map[string]any)
 
-	userId := fmt.Sprintf("users:%s", c.Param("userId"))
+	userId, err := sessionUserId(c, c.Param("userId"))
+	if err != nil {
+		return err
+	}
 	serverId := fmt.Sprintf("servers:%s", c.Param("serverId"))
 
 	server, err := s.db.GetServer(userId, serverId)
@@ -149,6 +162,11 @@ func (s *Server) HandlerJoinServer(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
+	if _, err := sessionUserId(c, body.User.ID); err != nil {
+		return err
+	}
+	user := sessionUser(c)
+
 	re := regexp.MustCompile(`^(https://hudori\.app/)?([a-zA-Z0-9]{8})$`)
 	match := re.FindStringSubmatch(body.InviteId)
 	if match == nil {
@@ -157,44 +175,32 @@ func (s *Server) HandlerJoinServer(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	server, err := s.db.JoinServer(body.User.ID, match[2])
+	server, err := s.db.JoinServer(user.ID, match[2])
 	if err != nil {
 		resp["name"] = "unexpected"
 		resp["message"] = err.Error()
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	if conn, ok := s.ws.sessions.Load(strings.Split(body.User.ID, ":")[1]); ok {
-		for _, channel := range server.ServerChannels {
-			Sub(globalEmitter, channel, &Socket{conn})
-		}
-		Sub(globalEmitter, server.Server.ID, &Socket{conn})
-	}
+	s.ws.SubscribeUser(strings.Split(user.ID, ":")[1], server.Server.ID, append(server.ServerChannels, server.Server.ID)...)
 
 	wsMess := &protoMess.WSMessage{
 		Type: "join_server",
 		Content: &protoMess.WSMessage_JoinServer{
 			JoinServer: &protoMess.JoinServer{
 				User: &protoMess.User{
-					Id:            body.User.ID,
-					Username:      body.User.Username,
-					DisplayName:   body.User.DisplayName,
-					UsernameColor: body.User.UsernameColor,
-					Avatar:        body.User.Avatar,
+					Id:            user.ID,
+					Username:      user.Username,
+					DisplayName:   user.DisplayName,
+					UsernameColor: user.UsernameColor,
+					Avatar:        user.Avatar,
 				},
 				ServerId: server.Server.ID,
 			},
 		},
 	}
 
-	data, err := proto.Marshal(wsMess)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
-
-	compMess := utils.CompressMess(data)
-	Pub(globalEmitter, server.Server.ID, gws.OpcodeBinary, compMess)
+	s.ws.Publish(server.Server.ID, wsMess)
 
 	resp["server"] = server.Server
 
@@ -213,24 +219,24 @@ func (s *Server) HandlerCreateServer(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
 	if len(body.Name) > 16 {
 		resp["name"] = "unexpected"
 		resp["message"] = "The name of your space is too long."
 	}
 
-	server, err := s.db.CreateServer(body.UserId, body.Name)
+	server, err := s.db.CreateServer(userId, body.Name)
 	if err != nil {
 		resp["name"] = "unexpected"
 		resp["message"] = err.Error()
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	if conn, ok := s.ws.sessions.Load(strings.Split(body.UserId, ":")[1]); ok {
-		for _, channel := range server.ServerChannels {
-			Sub(globalEmitter, channel, &Socket{conn})
-		}
-		Sub(globalEmitter, server.Server.ID, &Socket{conn})
-	}
+	s.ws.SubscribeUser(strings.Split(userId, ":")[1], server.Server.ID, append(server.ServerChannels, server.Server.ID)...)
 
 	resp["server"] = server.Server
 
@@ -249,7 +255,12 @@ func (s *Server) HandlerDeleteServer(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err := s.db.DeleteServer(body.UserId, body.ServerId)
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
+	channels, err := s.db.DeleteServer(userId, body.ServerId)
 	if err != nil {
 		resp["name"] = "unexpected"
 		resp["message"] = err.Error()
@@ -265,14 +276,8 @@ func (s *Server) HandlerDeleteServer(c echo.Context) error {
 		},
 	}
 
-	data, err := proto.Marshal(wsMess)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
-
-	compMess := utils.CompressMess(data)
-	Pub(globalEmitter, body.ServerId, gws.OpcodeBinary, compMess)
+	s.ws.Publish(body.ServerId, wsMess)
+	s.ws.Drop(append(channels, body.ServerId)...)
 
 	return c.JSON(http.StatusOK, resp)
 }
@@ -289,7 +294,12 @@ func (s *Server) HandlerLeaveServer(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err := s.db.LeaveServer(body.UserId, body.ServerId)
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
+	channels, err := s.db.LeaveServer(userId, body.ServerId)
 	if err != nil {
 		resp["name"] = "unexpected"
 		resp["message"] = err.Error()
@@ -303,19 +313,13 @@ func (s *Server) HandlerLeaveServer(c echo.Context) error {
 		Content: &protoMess.WSMessage_QuitServer{
 			QuitServer: &protoMess.QuitServer{
 				ServerId: body.ServerId,
-				UserId:   body.UserId,
+				UserId:   userId,
 			},
 		},
 	}
 
-	data, err := proto.Marshal(wsMess)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
-
-	compMess := utils.CompressMess(data)
-	Pub(globalEmitter, body.ServerId, gws.OpcodeBinary, compMess)
+	s.ws.Publish(body.ServerId, wsMess)
+	s.ws.UnsubscribeUser(strings.Split(userId, ":")[1], append(channels, body.ServerId)...)
 
 	return c.JSON(http.StatusOK, resp)
 }
@@ -332,7 +336,12 @@ func (s *Server) HandlerCreateInvitation(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	invitationId, err := s.db.CreateInvitation(body.UserId, body.ServerId)
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
+	invitationId, err := s.db.CreateInvitation(userId, body.ServerId)
 	if err != nil {
 		resp["name"] = "unexpected"
 		resp["message"] = err.Error()
@@ -378,43 +387,13 @@ func (s *Server) HandlerChangeServerIcon(c echo.Context) error {
 		return c.String(http.StatusInternalServerError, "Failed to read image")
 	}
 
-	imageBuffer := buf.Bytes()
-	mimeType := http.DetectContentType(imageBuffer)
+	imageToUpload, ext, err := cropImage(buf.Bytes(), cropX, cropY, cropWidth, cropHeight)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
 
-	var imageToUpload []byte
-	var iconKey string
 	randId, _ := utils.GenerateRandomId(6)
-	if mimeType == "image/gif" {
-		cmd := exec.Command("gifsicle",
-			"--crop", fmt.Sprintf("%d,%d+%dx%d", cropX, cropY, cropWidth, cropHeight),
-			"--lossy=90",
-			"--output", "-",
-			"--", "-",
-		)
-
-		cmd.Stdin = bytes.NewReader(imageBuffer)
-		var outputBuf bytes.Buffer
-		cmd.Stdout = &outputBuf
-
-		err = cmd.Run()
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to crop GIF with gifsicle")
-		}
-
-		imageToUpload = outputBuf.Bytes()
-		iconKey = strings.Split(serverId, ":")[1] + "-icon-" + randId + ".gif"
-	} else {
-		croppedImage, err := bimg.NewImage(imageBuffer).Extract(cropY, cropX, cropWidth, cropHeight)
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to crop image")
-		}
-
-		imageToUpload, err = bimg.NewImage(croppedImage).Convert(bimg.JPEG)
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to convert image to jpg")
-		}
-		iconKey = strings.Split(serverId, ":")[1] + "-icon-" + randId + ".jpg"
-	}
+	iconKey := strings.Split(serverId, ":")[1] + "-icon-" + randId + ext
 
 	if oldIconName != "" {
 		go func() {
@@ -469,15 +448,8 @@ func (s *Server) HandlerChangeServerIcon(c echo.Context) error {
 			},
 		},
 	}
-	data, err := proto.Marshal(wsMess)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
-
-	compMess := utils.CompressMess(data)
 	if serverId != "" {
-		Pub(globalEmitter, serverId, gws.OpcodeBinary, compMess)
+		s.ws.Publish(serverId, wsMess)
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -517,44 +489,13 @@ func (s *Server) HandlerChangeServerBanner(c echo.Context) error {
 		return c.String(http.StatusInternalServerError, "Failed to read image")
 	}
 
-	imageBuffer := buf.Bytes()
-	mimeType := http.DetectContentType(imageBuffer)
+	imageToUpload, ext, err := cropImage(buf.Bytes(), cropX, cropY, cropWidth, cropHeight)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
 
-	var imageToUpload []byte
-	var bannerKey string
 	randId, _ := utils.GenerateRandomId(6)
-	if mimeType == "image/gif" {
-		cmd := exec.Command("gifsicle",
-			"--crop", fmt.Sprintf("%d,%d+%dx%d", cropX, cropY, cropWidth, cropHeight),
-			"--lossy=90",
-			"--output", "-",
-			"--", "-",
-		)
-
-		cmd.Stdin = bytes.NewReader(imageBuffer)
-		var outputBuf bytes.Buffer
-		cmd.Stdout = &outputBuf
-
-		err = cmd.Run()
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to crop GIF with gifsicle")
-		}
-
-		imageToUpload = outputBuf.Bytes()
-		bannerKey = strings.Split(serverId, ":")[1] + "-banner-" + randId + ".gif"
-
-	} else {
-		croppedImage, err := bimg.NewImage(imageBuffer).Extract(cropY, cropX, cropWidth, cropHeight)
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to crop image")
-		}
-
-		imageToUpload, err = bimg.NewImage(croppedImage).Convert(bimg.JPEG)
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to convert image to jpg")
-		}
-		bannerKey = strings.Split(serverId, ":")[1] + "-banner-" + randId + ".jpg"
-	}
+	bannerKey := strings.Split(serverId, ":")[1] + "-banner-" + randId + ext
 
 	go func() {
 		res, err := s.s3.GetObject(&s3.GetObjectInput{
@@ -601,3 +542,58 @@ func (s *Server) HandlerChangeServerBanner(c echo.Context) error {
 	resp["message"] = "success"
 	return c.JSON(http.StatusOK, resp)
 }
+
+// HandlerChangeServerEditHistory sets who can see the previous versions of
+// edited messages in a server: every member, or only its moderators.
+func (s *Server) HandlerChangeServerEditHistory(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(EditHistoryBody)
+	if err := c.Bind(body); err != nil || (body.EditHistory != "members" && body.EditHistory != "moderators") {
+		resp["message"] = "The edit history can be visible to members or moderators."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	roles, err := s.db.GetMemberRoles(sessionUser(c).ID, body.ServerId)
+	if err != nil || !hasAnyRole(roles, adminRoles) {
+		resp["message"] = "You are not allowed to change the settings of this server."
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	if err := s.db.UpdateServerEditHistory(body.ServerId, body.EditHistory); err != nil {
+		resp["message"] = "An error occured when changing the settings of the server."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	resp["edit_history"] = body.EditHistory
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerChangeMentionableRole lets every member of a server mention a role,
+// or only its moderators.
+func (s *Server) HandlerChangeMentionableRole(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(MentionableRoleBody)
+	if err := c.Bind(body); err != nil || !roleName.MatchString(body.Role) {
+		resp["message"] = "A role name is made of up to 32 letters, digits, - and _."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	roles, err := s.db.GetMemberRoles(sessionUser(c).ID, body.ServerId)
+	if err != nil || !hasAnyRole(roles, adminRoles) {
+		resp["message"] = "You are not allowed to change the settings of this server."
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	mentionable, err := s.db.UpdateServerMentionableRole(body.ServerId, body.Role, body.Mentionable)
+	if err != nil {
+		resp["message"] = "An error occured when changing the settings of the server."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	resp["mentionable_roles"] = mentionable
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"goback/internal/models"
+	"goback/proto/protoMess"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// DefaultThreadAutoArchive is how many minutes a thread stays active
+	// without messages when its creator doesn't choose.
+	DefaultThreadAutoArchive = 1440
+
+	// ThreadArchiveInterval is how often inactive threads are archived.
+	ThreadArchiveInterval = time.Minute
+)
+
+var threadAutoArchives = []int{60, 1440, 4320, 10080}
+
+type createThreadBody struct {
+	ChannelId   string `json:"channel_id"`
+	MessageId   string `json:"message_id"`
+	Name        string `json:"name"`
+	AutoArchive int    `json:"auto_archive"`
+}
+
+type threadBody struct {
+	ThreadId    string `json:"thread_id"`
+	Name        string `json:"name"`
+	Archived    *bool  `json:"archived"`
+	AutoArchive int    `json:"auto_archive"`
+}
+
+// HandlerCreateThread starts a thread from a message of a channel.
+func (s *Server) HandlerCreateThread(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(createThreadBody)
+	if err := c.Bind(body); err != nil || body.ChannelId == "" || body.MessageId == "" {
+		resp["message"] = "An error occured when creating the thread."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	name := strings.TrimSpace(body.Name)
+	if name == "" || len(name) > 100 {
+		resp["message"] = "The name of a thread must be between 1 and 100 characters."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if body.AutoArchive == 0 {
+		body.AutoArchive = DefaultThreadAutoArchive
+	} else if !slices.Contains(threadAutoArchives, body.AutoArchive) {
+		resp["message"] = "Threads can be archived after 60, 1440, 4320 or 10080 minutes."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	if err := s.checkChannelAccess(userId, body.ChannelId, serverChannel); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	messageId := "messages:" + strings.TrimPrefix(body.MessageId, "messages:")
+	thread, err := s.db.CreateThread("channels:"+body.ChannelId, messageId, userId, name, body.AutoArchive)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	s.ws.SubscribeUser(strings.Split(userId, ":")[1], "", thread.ID)
+	s.publishThread("thread_create", thread)
+
+	resp["thread"] = thread
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerChannelThreads lists the active threads of a channel, or the
+// archived ones with ?archived=true.
+func (s *Server) HandlerChannelThreads(c echo.Context) error {
+	resp := make(map[string]any)
+
+	channelId := c.Param("channelId")
+	if err := s.checkChannelAccess(sessionUser(c).ID, channelId, serverChannel); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	threads, err := s.db.GetChannelThreads("channels:"+channelId, c.QueryParam("archived") == "true")
+	if err != nil {
+		resp["message"] = "An error occured when getting the threads."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	resp["threads"] = threads
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerJoinThread subscribes the user to a thread of a channel they are in.
+func (s *Server) HandlerJoinThread(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(threadBody)
+	if err := c.Bind(body); err != nil || body.ThreadId == "" {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	if err := s.checkChannelAccess(userId, body.ThreadId, serverChannel); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	threadId := "channels:" + body.ThreadId
+	if _, err := s.db.GetThread(threadId); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	if err := s.db.JoinThread(threadId, userId); err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+	s.ws.SubscribeUser(strings.Split(userId, ":")[1], "", threadId)
+
+	resp["message"] = "success"
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (s *Server) HandlerLeaveThread(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(threadBody)
+	if err := c.Bind(body); err != nil || body.ThreadId == "" {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	threadId := "channels:" + body.ThreadId
+	if _, err := s.db.GetThread(threadId); err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusNotFound, resp)
+	}
+
+	if err := s.db.LeaveThread(threadId, userId); err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+	s.ws.UnsubscribeUser(strings.Split(userId, ":")[1], threadId)
+
+	resp["message"] = "success"
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerUpdateThread lets the owner of a thread rename it, archive or
+// unarchive it and change its auto archive delay.
+func (s *Server) HandlerUpdateThread(c echo.Context) error {
+	resp := make(map[string]any)
+
+	body := new(threadBody)
+	if err := c.Bind(body); err != nil || body.ThreadId == "" {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	name := strings.TrimSpace(body.Name)
+	if len(name) > 100 {
+		resp["message"] = "The name of a thread must be between 1 and 100 characters."
+		return c.JSON(http.StatusBadRequest, resp)
+	} else if body.AutoArchive != 0 && !slices.Contains(threadAutoArchives, body.AutoArchive) {
+		resp["message"] = "Threads can be archived after 60, 1440, 4320 or 10080 minutes."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	threadId := "channels:" + body.ThreadId
+	thread, err := s.db.GetThread(threadId)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusNotFound, resp)
+	} else if thread.OwnerId != sessionUser(c).ID {
+		resp["message"] = "Only the owner of the thread can change it."
+		return c.JSON(http.StatusForbidden, resp)
+	}
+
+	thread, err = s.db.UpdateThread(threadId, name, body.Archived, body.AutoArchive)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	s.publishThread("thread_update", thread)
+
+	resp["thread"] = thread
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// touchThread counts a new message of a channel when it is a thread,
+// subscribing its author if they weren't, and tells the parent channel.
+func (s *Server) touchThread(channelId, authorId string) {
+	thread, joined, err := s.db.TouchThread(channelId, authorId)
+	if err != nil || thread == nil {
+		return
+	}
+
+	if joined {
+		s.ws.SubscribeUser(strings.Split(authorId, ":")[1], "", thread.ID)
+	}
+	s.publishThread("thread_update", *thread)
+}
+
+// archiveThreads periodically archives the threads that have been inactive
+// for longer than their auto archive delay.
+func (s *Server) archiveThreads() {
+	ticker := time.NewTicker(ThreadArchiveInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		threads, err := s.db.ArchiveInactiveThreads()
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		for _, thread := range threads {
+			s.publishThread("thread_update", thread)
+		}
+	}
+}
+
+// publishThread tells the parent channel of a thread that it was created or
+// changed, so its starter message shows the new metadata.
+func (s *Server) publishThread(eventType string, thread models.Thread) {
+	s.ws.Publish(thread.ParentChannelId, &protoMess.WSMessage{
+		Type: eventType,
+		Content: &protoMess.WSMessage_Thread{
+			Thread: &protoMess.Thread{
+				Id:              thread.ID,
+				Name:            thread.Name,
+				ParentChannelId: thread.ParentChannelId,
+				ParentMessageId: thread.ParentMessageId,
+				OwnerId:         thread.OwnerId,
+				MessageCount:    int32(thread.MessageCount),
+				LastMessageAt:   thread.LastMessageAt,
+				Archived:        thread.Archived,
+				AutoArchive:     int32(thread.AutoArchive),
+				CreatedAt:       thread.CreatedAt,
+			},
+		},
+	})
+}
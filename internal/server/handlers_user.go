@@ -3,23 +3,19 @@ package server
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"goback/internal/utils"
 	"goback/proto/protoMess"
 	"io"
 	"log"
 	"net/http"
-	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/h2non/bimg"
 	"github.com/labstack/echo/v4"
-	"github.com/lxzan/gws"
-	"google.golang.org/protobuf/proto"
 )
 
 type ChangeInformations struct {
@@ -42,7 +38,12 @@ func (s *Server) HandlerChangeEmail(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err := s.db.ChangeEmail(body.UserId, *body.Email)
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.ChangeEmail(userId, *body.Email)
 	if err != nil {
 		log.Println(err)
 		resp["name"] = "email"
@@ -83,14 +84,19 @@ func (s *Server) HandlerChangeUsername(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	_, err := s.db.GetUser("", *body.Username, "")
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.GetUser("", *body.Username, "")
 	if err == nil {
 		resp["name"] = "username"
 		resp["message"] = "This username is already in use."
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err = s.db.ChangeUsername(body.UserId, *body.Username)
+	err = s.db.ChangeUsername(userId, *body.Username)
 	if err != nil {
 		log.Println(err)
 		resp["name"] = "username"
@@ -114,7 +120,12 @@ func (s *Server) HandlerChangeDisplayName(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err := s.db.ChangeDisplayName(body.UserId, *body.DisplayName)
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.ChangeDisplayName(userId, *body.DisplayName)
 	if err != nil {
 		log.Println(err)
 		resp["name"] = "display_name"
@@ -137,7 +148,12 @@ func (s *Server) HandlerChangeNameColor(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	err := s.db.ChangeNameColor(body.UserId, *body.UsernameColor)
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.ChangeNameColor(userId, *body.UsernameColor)
 	if err != nil {
 		log.Println(err)
 		resp["message"] = "An error occured when changing your informations."
@@ -159,7 +175,7 @@ func (s *Server) HandlerChangeBanner(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	userId := strings.Split(c.Request().Header.Get("X-User-ID"), ":")[1]
+	userId := sessionUserKey(c)
 
 	cropX, _ := strconv.Atoi(c.FormValue("cropX"))
 	cropY, _ := strconv.Atoi(c.FormValue("cropY"))
@@ -191,43 +207,13 @@ func (s *Server) HandlerChangeBanner(c echo.Context) error {
 		return c.String(http.StatusInternalServerError, "Failed to read image")
 	}
 
-	imageBuffer := buf.Bytes()
-	mimeType := http.DetectContentType(imageBuffer)
+	imageToUpload, ext, err := cropImage(buf.Bytes(), cropX, cropY, cropWidth, cropHeight)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
 
-	var imageToUpload []byte
-	var bannerKey string
 	randId, _ := utils.GenerateRandomId(6)
-	if mimeType == "image/gif" {
-		cmd := exec.Command("gifsicle",
-			"--crop", fmt.Sprintf("%d,%d+%dx%d", cropX, cropY, cropWidth, cropHeight),
-			"--lossy=90",
-			"--output", "-",
-			"--", "-",
-		)
-
-		cmd.Stdin = bytes.NewReader(imageBuffer)
-		var outputBuf bytes.Buffer
-		cmd.Stdout = &outputBuf
-
-		err = cmd.Run()
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to crop GIF with gifsicle")
-		}
-
-		imageToUpload = outputBuf.Bytes()
-		bannerKey = userId + "-banner-" + randId + ".gif"
-	} else {
-		croppedImage, err := bimg.NewImage(imageBuffer).Extract(cropY, cropX, cropWidth, cropHeight)
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to crop image")
-		}
-
-		imageToUpload, err = bimg.NewImage(croppedImage).Convert(bimg.JPEG)
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to convert image to jpg")
-		}
-		bannerKey = userId + "-banner-" + randId + ".jpg"
-	}
+	bannerKey := userId + "-banner-" + randId + ext
 
 	go func() {
 		res, err := s.s3.GetObject(&s3.GetObjectInput{
@@ -290,7 +276,7 @@ func (s *Server) HandlerChangeAvatar(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, resp)
 	}
 
-	userId := strings.Split(c.Request().Header.Get("X-User-ID"), ":")[1]
+	userId := sessionUserKey(c)
 
 	cropX, _ := strconv.Atoi(c.FormValue("cropX"))
 	cropY, _ := strconv.Atoi(c.FormValue("cropY"))
@@ -332,43 +318,13 @@ func (s *Server) HandlerChangeAvatar(c echo.Context) error {
 		return c.String(http.StatusInternalServerError, "Failed to read image")
 	}
 
-	imageBuffer := buf.Bytes()
-	mimeType := http.DetectContentType(imageBuffer)
+	imageToUpload, ext, err := cropImage(buf.Bytes(), cropX, cropY, cropWidth, cropHeight)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
 
-	var imageToUpload []byte
-	var avatarKey string
 	randId, _ := utils.GenerateRandomId(6)
-	if mimeType == "image/gif" {
-		cmd := exec.Command("gifsicle",
-			"--crop", fmt.Sprintf("%d,%d+%dx%d", cropX, cropY, cropWidth, cropHeight),
-			"--lossy=90",
-			"--output", "-",
-			"--", "-",
-		)
-
-		cmd.Stdin = bytes.NewReader(imageBuffer)
-		var outputBuf bytes.Buffer
-		cmd.Stdout = &outputBuf
-
-		err = cmd.Run()
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to crop GIF with gifsicle")
-		}
-
-		imageToUpload = outputBuf.Bytes()
-		avatarKey = userId + "-avatar-" + randId + ".gif"
-	} else {
-		croppedImage, err := bimg.NewImage(imageBuffer).Extract(cropY, cropX, cropWidth, cropHeight)
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to crop image")
-		}
-
-		imageToUpload, err = bimg.NewImage(croppedImage).Convert(bimg.JPEG)
-		if err != nil {
-			return c.String(http.StatusInternalServerError, "Failed to convert image to jpg")
-		}
-		avatarKey = userId + "-avatar-" + randId + ".jpg"
-	}
+	avatarKey := userId + "-avatar-" + randId + ext
 
 	go func() {
 		res, err := s.s3.GetObject(&s3.GetObjectInput{
@@ -421,22 +377,13 @@ func (s *Server) HandlerChangeAvatar(c echo.Context) error {
 			},
 		},
 	}
-	data, err := proto.Marshal(wsMess)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
-
-	compMess := utils.CompressMess(data)
 	if serverId != "" {
-		Pub(globalEmitter, serverId, gws.OpcodeBinary, compMess)
+		s.ws.Publish(serverId, wsMess)
 	}
 
 	if len(friends) > 0 {
 		for _, friend := range friends {
-			if connFriend, ok := s.ws.sessions.Load(strings.Split(friend, ":")[1]); ok {
-				connFriend.WriteMessage(gws.OpcodeBinary, compMess)
-			}
+			s.ws.SendToUser(strings.Split(friend, ":")[1], wsMess)
 		}
 	}
 
@@ -444,6 +391,9 @@ func (s *Server) HandlerChangeAvatar(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+// preferredStatuses are the statuses a user can pick.
+var preferredStatuses = []string{"online", "idle", "dnd", "invisible"}
+
 func (s *Server) HandlerChangeStatus(c echo.Context) error {
 	body := new(ChangeInformations)
 	if err := c.Bind(body); err != nil {
@@ -451,18 +401,41 @@ func (s *Server) HandlerChangeStatus(c echo.Context) error {
 		return c.String(400, "")
 	}
 
-	err := s.db.UpdateUserStatus(strings.Split(body.UserId, ":")[1], *body.Status)
+	userId, err := sessionUserId(c, body.UserId)
+	if err != nil {
+		return err
+	}
+
+	if body.Status == nil || !slices.Contains(preferredStatuses, *body.Status) {
+		return c.String(400, "")
+	}
+
+	userKey := strings.Split(userId, ":")[1]
+	err = s.db.UpdatePreferredStatus(userKey, *body.Status)
 	if err != nil {
 		log.Println(err)
 		return c.String(400, "")
 	}
 
-	servers, err := s.db.GetUserServers(body.UserId)
+	s.ws.presence.SetPreferred(userKey, *body.Status)
+
+	return c.String(200, "")
+}
+
+// broadcastStatus saves the effective status of a user computed by the
+// presence tracker and sends it to their servers and friends.
+func (s *Server) broadcastStatus(userId, status string) {
+	err := s.db.UpdateUserStatus(userId, status)
 	if err != nil {
 		log.Println(err)
 	}
 
-	friends, err := s.db.GetFriends(body.UserId)
+	servers, err := s.db.GetUserServers("users:" + userId)
+	if err != nil {
+		log.Println(err)
+	}
+
+	friends, err := s.db.GetFriends("users:" + userId)
 	if err != nil {
 		log.Println(err)
 	}
@@ -471,29 +444,17 @@ func (s *Server) HandlerChangeStatus(c echo.Context) error {
 		Type: "change_status",
 		Content: &protoMess.WSMessage_ChangeStatus{
 			ChangeStatus: &protoMess.ChangeStatus{
-				UserId: body.UserId,
-				Status: *body.Status,
+				UserId: "users:" + userId,
+				Status: status,
 			},
 		},
 	}
 
-	data, err := proto.Marshal(wsMess)
-	if err != nil {
-		log.Println(err)
-		return err
-	}
-
-	compMess := utils.CompressMess(data)
-
-	for _, s := range servers {
-		Pub(globalEmitter, s.ID, gws.OpcodeBinary, compMess)
+	for _, server := range servers {
+		s.ws.Publish(server.ID, wsMess)
 	}
 
 	for _, f := range friends {
-		if connFriend, ok := s.ws.sessions.Load(strings.Split(f.ID, ":")[1]); ok {
-			connFriend.WriteMessage(gws.OpcodeBinary, compMess)
-		}
+		s.ws.SendToUser(strings.Split(f.ID, ":")[1], wsMess)
 	}
-
-	return c.String(200, "")
 }
@@ -1,84 +1,204 @@
 package server
 
 import (
+	"fmt"
+	"goback/internal/models"
 	"goback/internal/utils"
-	"goback/proto/protoMess"
 	"log"
-	"math/rand"
+	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/lxzan/gws"
-	"google.golang.org/protobuf/proto"
 )
 
 // WEBSOCKET
-func (s *Server) HandlerWebsocket(c echo.Context) error {
-	upgrader := NewWebsocketUpgrader(s.ws)
+func (s *Server) HandlerWebsocketTicket(c echo.Context) error {
+	resp := make(map[string]any)
 
-	so, err := upgrader.Upgrade(c.Response(), c.Request())
+	sess := c.Get("session").(models.Session)
+
+	ticket, err := s.db.CreateWebsocketTicket(sess.ID, sess.UserId)
 	if err != nil {
-		return err
+		resp["message"] = "An error occured when creating the websocket ticket."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	resp["ticket"] = ticket
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerDebugSubscriptions lists the topics this instance listens to and
+// how many clients are subscribed to each of them, along with the state of
+// the outbound queues of the clients.
+func (s *Server) HandlerDebugSubscriptions(c echo.Context) error {
+	resp := make(map[string]any)
+
+	resp["topics"] = s.ws.broker.Subscriptions()
+	resp["clients"] = s.ws.sessions.Count()
+	resp["queues"] = s.queueMetrics()
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandlerWebsocketMetrics reports the number of clients of this instance and
+// the state of their outbound queues.
+func (s *Server) HandlerWebsocketMetrics(c echo.Context) error {
+	resp := make(map[string]any)
+
+	resp["clients"] = s.ws.sessions.Count()
+	resp["queues"] = s.queueMetrics()
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (s *Server) queueMetrics() map[string]any {
+	queued, maxDepth := 0, 0
+	for _, client := range s.ws.sessions.All() {
+		depth := client.QueueDepth()
+		queued += depth
+		maxDepth = max(maxDepth, depth)
+	}
+
+	return map[string]any{
+		"size":                 queueSize,
+		"queued":               queued,
+		"max_depth":            maxDepth,
+		"dropped":              droppedEvents.Load(),
+		"overflow_disconnects": overflowDisconnects.Load(),
 	}
+}
+
+// websocketSession authenticates an upgrade request, either with a
+// single-use ticket from HandlerWebsocketTicket or with the session cookie.
+func (s *Server) websocketSession(c echo.Context) (models.Session, error) {
+	if ticket := c.QueryParam("ticket"); ticket != "" {
+		t, err := s.db.ConsumeWebsocketTicket(ticket)
+		if err != nil {
+			return models.Session{}, err
+		}
 
-	socket := &Socket{so}
-	userIdMain := c.Param("userId")
-	socket.Conn.Session().Store("userIdMain", userIdMain)
-	socket.Conn.Session().Store("userIdEmitter", rand.Int63())
+		expiresAt, err := time.Parse(time.RFC3339, t.ExpiresAt)
+		if err != nil {
+			return models.Session{}, err
+		} else if time.Now().After(expiresAt) {
+			return models.Session{}, fmt.Errorf("the ticket has expired")
+		}
 
-	err = s.db.UpdateUserStatus(userIdMain, "online")
+		return s.authenticateSession(t.SessionId)
+	}
+
+	sessionCookie, err := c.Cookie("session")
 	if err != nil {
-		log.Println(err)
+		return models.Session{}, err
 	}
 
-	go func() {
-		socket.ReadLoop()
-	}()
+	return s.authenticateSession(sessionCookie.Value)
+}
 
-	servers, err := s.db.GetUserServers("users:" + userIdMain)
+func (s *Server) HandlerWebsocket(c echo.Context) error {
+	sess, err := s.websocketSession(c)
 	if err != nil {
 		log.Println(err)
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid session")
+	}
+
+	userIdMain := strings.Split(sess.UserId, ":")[1]
+	if userId := c.Param("userId"); userId != "" && userId != userIdMain {
+		return errForbiddenIdentity
 	}
-	friends, err := s.db.GetFriends("users:" + userIdMain)
+
+	sessionExpire, err := time.Parse(time.RFC3339, sess.ExpiresdAt)
 	if err != nil {
-		log.Println(err)
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid session")
 	}
-	channels, err := s.db.GetSubscribedChannels(userIdMain)
+
+	format, err := ParseFormat(c.QueryParam("encoding"), c.QueryParam("compression"))
 	if err != nil {
-		log.Println(err)
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	wsMess := &protoMess.WSMessage{
-		Type: "change_status",
-		Content: &protoMess.WSMessage_ChangeStatus{
-			ChangeStatus: &protoMess.ChangeStatus{
-				UserId: "users:" + userIdMain,
-				Status: "online",
-			},
-		},
+	intents, err := ParseIntents(c.QueryParam("intents"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	data, err := proto.Marshal(wsMess)
+	upgrader := NewWebsocketUpgrader(s.ws)
+
+	socket, err := upgrader.Upgrade(c.Response(), c.Request())
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 
-	compMess := utils.CompressMess(data)
-	for _, server := range servers {
-		Pub(globalEmitter, server.ID, gws.OpcodeBinary, compMess)
-		Sub(globalEmitter, server.ID, socket)
+	client, resumed := s.ws.Resume(c.QueryParam("resume"), userIdMain, sess.ID)
+	if !resumed {
+		connId := c.QueryParam("device")
+		if connId == "" || len(connId) > 64 {
+			connId, _ = utils.GenerateRandomId(12)
+		}
+		client = NewClient(userIdMain, connId, sess.ID)
+		client.Intents = intents
+		client.Lazy = c.QueryParam("lazy") == "true"
+		s.ws.Subscribe(userTopic(userIdMain), client)
 	}
 
-	for _, f := range friends {
-		if connFriend, ok := s.ws.sessions.Load(strings.Split(f.ID, ":")[1]); ok {
-			connFriend.WriteMessage(gws.OpcodeBinary, compMess)
-		}
+	socket.Session().Store("userIdMain", userIdMain)
+	socket.Session().Store("client", client)
+	socket.Session().Store("resumed", resumed)
+	socket.Session().Store("format", format)
+	socket.Session().Store("sessionTimer", time.AfterFunc(time.Until(sessionExpire), func() {
+		s.ws.drop(client, CloseSessionRevoked, "session expired")
+	}))
+	if c.QueryParam("resume") != "" {
+		lastSeq, _ := strconv.ParseUint(c.QueryParam("seq"), 10, 64)
+		socket.Session().Store("resumeSeq", lastSeq)
 	}
 
-	for _, channel := range channels {
-		Sub(globalEmitter, channel.ID, socket)
+	user, err := s.db.GetUser(sess.UserId, "", "")
+	if err != nil {
+		log.Println(err)
 	}
 
+	preferred := "online"
+	if user.PreferredStatus != "" {
+		preferred = user.PreferredStatus
+	}
+	socket.Session().Store("preferredStatus", preferred)
+
+	// A new client is subscribed before its READY is built, so no event is
+	// missed between the two, and attach sends READY before replaying
+	// them. Lazy clients subscribe to servers as they open them with a
+	// servers command.
+	if !resumed {
+		servers, err := s.db.GetUserServersWithChannels(sess.UserId)
+		if err != nil {
+			log.Println(err)
+		}
+
+		if !client.Lazy {
+			channels, err := s.db.GetSubscribedChannels(userIdMain)
+			if err != nil {
+				log.Println(err)
+			}
+
+			for _, server := range servers {
+				s.ws.Subscribe(server.ID, client)
+			}
+
+			for _, channel := range channels {
+				s.ws.Subscribe(channel.ID, client)
+			}
+		}
+
+		ready := NewEvent(s.buildReady(user, servers))
+		socket.Session().Store("ready", &ready)
+	}
+
+	go func() {
+		socket.ReadLoop()
+	}()
+
 	return nil
 }
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os/exec"
+
+	"github.com/h2non/bimg"
+)
+
+// cropImage crops an uploaded picture, GIFs staying animated and everything
+// else being converted to JPEG, and returns it with its extension.
+func cropImage(image []byte, cropX, cropY, cropWidth, cropHeight int) ([]byte, string, error) {
+	if http.DetectContentType(image) == "image/gif" {
+		cmd := exec.Command("gifsicle",
+			"--crop", fmt.Sprintf("%d,%d+%dx%d", cropX, cropY, cropWidth, cropHeight),
+			"--lossy=90",
+			"--output", "-",
+			"--", "-",
+		)
+
+		cmd.Stdin = bytes.NewReader(image)
+		var outputBuf bytes.Buffer
+		cmd.Stdout = &outputBuf
+
+		if err := cmd.Run(); err != nil {
+			return nil, "", fmt.Errorf("Failed to crop GIF with gifsicle")
+		}
+
+		return outputBuf.Bytes(), ".gif", nil
+	}
+
+	croppedImage, err := bimg.NewImage(image).Extract(cropY, cropX, cropWidth, cropHeight)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to crop image")
+	}
+
+	converted, err := bimg.NewImage(croppedImage).Convert(bimg.JPEG)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to convert image to jpg")
+	}
+
+	return converted, ".jpg", nil
+}
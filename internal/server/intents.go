@@ -0,0 +1,74 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Intents are the categories of events a socket opts into at connect time
+// with the intents query parameter, e.g. ?intents=messages,voice. They only
+// filter what is published to servers and channels, events sent to the user
+// directly are always delivered.
+type Intents uint8
+
+const (
+	IntentMessages Intents = 1 << iota
+	IntentPresence
+	IntentTyping
+	IntentVoice
+	IntentMembers
+
+	AllIntents = IntentMessages | IntentPresence | IntentTyping | IntentVoice | IntentMembers
+)
+
+var intentNames = map[string]Intents{
+	"messages": IntentMessages,
+	"presence": IntentPresence,
+	"typing":   IntentTyping,
+	"voice":    IntentVoice,
+	"members":  IntentMembers,
+}
+
+// eventIntents maps event types to the intent they need. Other events, like
+// channels being created, are delivered to every socket.
+var eventIntents = map[string]Intents{
+	"text_message":    IntentMessages,
+	"edit_message":    IntentMessages,
+	"delete_message":  IntentMessages,
+	"reaction_add":    IntentMessages,
+	"reaction_remove": IntentMessages,
+	"thread_create":   IntentMessages,
+	"thread_update":   IntentMessages,
+	"pins_update":     IntentMessages,
+	"change_status":   IntentPresence,
+	"typing":          IntentTyping,
+	"voice_state":     IntentVoice,
+	"join_server":     IntentMembers,
+	"leave_server":    IntentMembers,
+	"new_avatar":      IntentMembers,
+}
+
+// ParseIntents parses a comma separated list of intents, every intent being
+// enabled when the list is empty.
+func ParseIntents(list string) (Intents, error) {
+	if list == "" {
+		return AllIntents, nil
+	}
+
+	var intents Intents
+	for _, name := range strings.Split(list, ",") {
+		intent, ok := intentNames[strings.TrimSpace(name)]
+		if !ok {
+			return 0, fmt.Errorf("unknown intent %q", name)
+		}
+		intents |= intent
+	}
+
+	return intents, nil
+}
+
+// Has reports whether the intents include those needed by an event type.
+func (i Intents) Has(eventType string) bool {
+	intent, ok := eventIntents[eventType]
+	return !ok || i&intent != 0
+}
@@ -0,0 +1,50 @@
+package server
+
+import "testing"
+
+func TestParseIntents(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    Intents
+		wantErr bool
+	}{
+		{list: "", want: AllIntents},
+		{list: "messages", want: IntentMessages},
+		{list: "messages, voice", want: IntentMessages | IntentVoice},
+		{list: "typing,typing", want: IntentTyping},
+		{list: "messages,emails", wantErr: true},
+		{list: ",", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		intents, err := ParseIntents(tt.list)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseIntents(%q) error = %v", tt.list, err)
+			continue
+		}
+		if intents != tt.want {
+			t.Errorf("ParseIntents(%q) = %b, expected %b", tt.list, intents, tt.want)
+		}
+	}
+}
+
+func TestIntentsHas(t *testing.T) {
+	tests := []struct {
+		intents   Intents
+		eventType string
+		want      bool
+	}{
+		{intents: IntentMessages, eventType: "text_message", want: true},
+		{intents: IntentMessages, eventType: "typing", want: false},
+		{intents: IntentTyping | IntentPresence, eventType: "change_status", want: true},
+		{intents: 0, eventType: "voice_state", want: false},
+		{intents: 0, eventType: "create_channel", want: true},
+		{intents: AllIntents, eventType: "join_server", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.intents.Has(tt.eventType); got != tt.want {
+			t.Errorf("%b.Has(%q) = %v", tt.intents, tt.eventType, got)
+		}
+	}
+}
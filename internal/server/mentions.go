@@ -0,0 +1,180 @@
+package server
+
+import (
+	"goback/internal/models"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// MaxMessageMentions is how many different users, roles and channels a
+// message can mention, the following ones are left as text.
+const MaxMessageMentions = 50
+
+// mentionPattern matches <@user>, <@&role>, <#channel>, @everyone and @here,
+// users and channels being given by id with or without their table.
+var mentionPattern = regexp.MustCompile(`<@&([\w-]{1,32})>|<@(?:users:)?(\w{1,64})>|<#(?:channels:)?(\w{1,64})>|(?:^|\W)@(everyone|here)\b`)
+
+var roleName = regexp.MustCompile(`^[\w-]{1,32}$`)
+
+// mentions are the users, roles and channels a message mentions. Users and
+// channels are full ids.
+type mentions struct {
+	users    []string
+	roles    []string
+	channels []string
+	everyone bool
+	here     bool
+
+	// serverId is the server the mentions were resolved in.
+	serverId string
+}
+
+// parseMentions finds the mentions written in the content of a message,
+// without checking that they exist.
+func parseMentions(content string) mentions {
+	m := mentions{
+		users:    make([]string, 0),
+		roles:    make([]string, 0),
+		channels: make([]string, 0),
+	}
+
+	count := 0
+	add := func(list *[]string, value string) {
+		if count < MaxMessageMentions && !slices.Contains(*list, value) {
+			*list = append(*list, value)
+			count++
+		}
+	}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		switch {
+		case match[1] != "":
+			add(&m.roles, match[1])
+		case match[2] != "":
+			add(&m.users, "users:"+match[2])
+		case match[3] != "":
+			add(&m.channels, "channels:"+match[3])
+		case match[4] == "everyone":
+			m.everyone = true
+		case match[4] == "here":
+			m.here = true
+		}
+	}
+
+	return m
+}
+
+// resolveMentions keeps the mentions of a message that make sense where it
+// is written. In servers, those are its members, its channels, the roles
+// the author can mention and @everyone and @here for moderators. In
+// conversations, only their users can be mentioned.
+func (s *Server) resolveMentions(authorId, channelId string, conv conversation, content string) (mentions, error) {
+	m := parseMentions(content)
+
+	var users []string
+	switch conv {
+	case privateConversation:
+		users = []string{authorId, "users:" + channelId}
+	case groupConversation:
+		var err error
+		users, err = s.db.GetDMUsers("dm_channels:" + channelId)
+		if err != nil {
+			return m, err
+		}
+	}
+	if conv != serverChannel {
+		m.users = slices.DeleteFunc(m.users, func(user string) bool {
+			return !slices.Contains(users, user)
+		})
+		m.roles, m.channels = m.roles[:0], m.channels[:0]
+		m.everyone, m.here = false, false
+
+		return m, nil
+	}
+
+	server, err := s.db.GetChannelServer("channels:" + channelId)
+	if err != nil {
+		return m, err
+	}
+	m.serverId = server.ID
+
+	if len(m.users) > 0 {
+		if m.users, err = s.db.FilterServerMembers(server.ID, m.users); err != nil {
+			return m, err
+		}
+	}
+	if len(m.channels) > 0 {
+		if m.channels, err = s.db.FilterServerChannels(server.ID, m.channels); err != nil {
+			return m, err
+		}
+	}
+
+	if len(m.roles) == 0 && !m.everyone && !m.here {
+		return m, nil
+	}
+
+	authorRoles, err := s.db.GetMemberRoles(authorId, server.ID)
+	if err != nil {
+		return m, err
+	}
+	moderator := hasAnyRole(authorRoles, moderatorRoles)
+	m.everyone = m.everyone && moderator
+	m.here = m.here && moderator
+
+	if len(m.roles) > 0 {
+		roles, err := s.db.GetServerRoles(server.ID)
+		if err != nil {
+			return m, err
+		}
+		m.roles = slices.DeleteFunc(m.roles, func(role string) bool {
+			return !slices.Contains(roles, role) || (!moderator && !slices.Contains(server.MentionableRoles, role))
+		})
+	}
+
+	return m, nil
+}
+
+// apply stores the mentions in a message.
+func (m mentions) apply(message *models.Message) {
+	message.Mentions = append(make([]string, 0), m.users...)
+	message.MentionRoles = append(make([]string, 0), m.roles...)
+	message.MentionChannels = append(make([]string, 0), m.channels...)
+	message.MentionEveryone = m.everyone
+	message.MentionHere = m.here
+}
+
+// mentionedUsers returns every user a message mentions: directly and, in
+// servers, through one of their roles, with @everyone or, when they are
+// online, with @here.
+func (s *Server) mentionedUsers(channelId string, m mentions) []string {
+	users := append(make([]string, 0), m.users...)
+	if m.serverId == "" {
+		return users
+	}
+
+	if len(m.roles) > 0 {
+		members, err := s.db.GetRoleMembers(m.serverId, m.roles)
+		if err != nil {
+			log.Println(err)
+		}
+		users = append(users, members...)
+	}
+
+	if m.everyone || m.here {
+		subscribers, err := s.db.GetUsersFromChannel("channels:" + channelId)
+		if err != nil {
+			log.Println(err)
+		}
+		for _, user := range subscribers {
+			if m.everyone || s.ws.presence.Status(strings.Split(user, ":")[1]) != "offline" {
+				users = append(users, user)
+			}
+		}
+	}
+
+	slices.Sort(users)
+
+	return slices.Compact(users)
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	m := parseMentions("<@alice> and <@users:bob> ask <@&moderator> to read <#channels:rules>, <#faq> and <@alice> @here")
+
+	if !slices.Equal(m.users, []string{"users:alice", "users:bob"}) {
+		t.Errorf("users = %v", m.users)
+	}
+	if !slices.Equal(m.roles, []string{"moderator"}) {
+		t.Errorf("roles = %v", m.roles)
+	}
+	if !slices.Equal(m.channels, []string{"channels:rules", "channels:faq"}) {
+		t.Errorf("channels = %v", m.channels)
+	}
+	if !m.here || m.everyone {
+		t.Errorf("here = %v, everyone = %v", m.here, m.everyone)
+	}
+}
+
+func TestParseMentionsIgnoresAddresses(t *testing.T) {
+	m := parseMentions("write to team@everyone.com or <@ alice>")
+
+	if m.everyone || len(m.users) != 0 {
+		t.Errorf("everyone = %v, users = %v", m.everyone, m.users)
+	}
+}
+
+func TestParseMentionsLimit(t *testing.T) {
+	var content string
+	for i := range MaxMessageMentions + 10 {
+		content += "<@user" + string(rune('a'+i%26)) + string(rune('a'+i/26)) + "> "
+	}
+
+	if m := parseMentions(content); len(m.users) != MaxMessageMentions {
+		t.Errorf("%d users, expected %d", len(m.users), MaxMessageMentions)
+	}
+}
@@ -1,9 +1,19 @@
 package server
 
 import (
+	"crypto/subtle"
+	"fmt"
+	"goback/internal/models"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/labstack/echo/v4"
 )
 
+var errForbiddenIdentity = echo.NewHTTPError(http.StatusForbidden, "You are not allowed to act on behalf of another user.")
+
 func (s *Server) SessionAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		sessionCookie, err := c.Cookie("session")
@@ -11,7 +21,7 @@ func (s *Server) SessionAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return echo.NewHTTPError(404, "No session cookie available")
 		}
 
-		sess, err := s.db.GetSession(sessionCookie.Value)
+		sess, err := s.authenticateSession(sessionCookie.Value)
 		if err != nil {
 			return echo.NewHTTPError(403, "Invalid session")
 		}
@@ -21,6 +31,75 @@ func (s *Server) SessionAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return echo.NewHTTPError(403, "Invalid session")
 		}
 
+		user, err := s.db.GetUser(sess.UserId, "", "")
+		if err != nil {
+			return echo.NewHTTPError(403, "Invalid session")
+		}
+		user.Password = ""
+
+		c.Set("session", sess)
+		c.Set("user", user)
+
+		return next(c)
+	}
+}
+
+// MetricsAuthMiddleware restricts internal endpoints to the holders of
+// METRICS_TOKEN, sent as a bearer token. They don't exist without it.
+func (s *Server) MetricsAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := os.Getenv("METRICS_TOKEN")
+		if token == "" {
+			return echo.ErrNotFound
+		}
+
+		auth := c.Request().Header.Get(echo.HeaderAuthorization)
+		if subtle.ConstantTimeCompare([]byte(auth), []byte("Bearer "+token)) != 1 {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+		}
+
 		return next(c)
 	}
 }
+
+// authenticateSession loads a session and makes sure it hasn't expired.
+func (s *Server) authenticateSession(sessionId string) (models.Session, error) {
+	sess, err := s.db.GetSession(sessionId)
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, sess.ExpiresdAt)
+	if err != nil {
+		return models.Session{}, err
+	} else if time.Now().After(expiresAt) {
+		return models.Session{}, fmt.Errorf("the session has expired")
+	}
+
+	return sess, nil
+}
+
+// sessionUser returns the user loaded by SessionAuthMiddleware.
+func sessionUser(c echo.Context) models.User {
+	return c.Get("user").(models.User)
+}
+
+// sessionUserId returns the full record id of the session user, rejecting
+// any identity sent by the client that doesn't match it. Empty claims are
+// ignored so handlers can still be called without them.
+func sessionUserId(c echo.Context, claims ...string) (string, error) {
+	userId := sessionUser(c).ID
+	for _, claim := range claims {
+		if claim != "" && claim != userId && "users:"+claim != userId {
+			return "", errForbiddenIdentity
+		}
+	}
+
+	return userId, nil
+}
+
+// sessionUserKey returns the session user id without its table prefix, the
+// form used as key for websocket connections and storage paths.
+func sessionUserKey(c echo.Context) string {
+	return strings.Split(sessionUser(c).ID, ":")[1]
+}
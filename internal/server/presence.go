@@ -0,0 +1,281 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// PresenceGracePeriod is how long a user stays visible after their last
+// connection closed, so a page reload or a flaky network doesn't flash them
+// offline for their friends.
+const PresenceGracePeriod = 15 * time.Second
+
+// Presence tracks the connections of every user and derives the status shown
+// to their friends and servers from them. onChange is only called when that
+// status actually changes, one change at a time and in order.
+//
+// Changes go through the broker so every instance knows the connections of
+// the others: a user is only offline once none of them holds one. onChange
+// is called by the instance the change was made on.
+type Presence struct {
+	mu       sync.Mutex
+	users    map[string]*userPresence
+	control  func(cmd Command)
+	pending  []statusChange
+	wake     chan struct{}
+	onChange func(userId, status string)
+}
+
+type userPresence struct {
+	preferred string
+	status    string
+	conns     map[string]*presenceConn
+	offline   *time.Timer
+}
+
+type presenceConn struct {
+	idle  bool
+	local bool
+}
+
+type statusChange struct {
+	userId string
+	status string
+}
+
+func NewPresence(control func(cmd Command), onChange func(userId, status string)) *Presence {
+	p := &Presence{
+		users:    make(map[string]*userPresence),
+		control:  control,
+		wake:     make(chan struct{}, 1),
+		onChange: onChange,
+	}
+
+	go func() {
+		for range p.wake {
+			p.mu.Lock()
+			changes := p.pending
+			p.pending = nil
+			p.mu.Unlock()
+
+			for _, change := range changes {
+				p.onChange(change.userId, change.status)
+			}
+		}
+	}()
+
+	return p
+}
+
+// effectiveStatus is "offline" without connections, the status the user
+// picked when it isn't "online", and "idle" when every device is idle.
+func (u *userPresence) effectiveStatus() string {
+	if len(u.conns) == 0 || u.preferred == "invisible" || u.preferred == "offline" {
+		return "offline"
+	} else if u.preferred != "" && u.preferred != "online" {
+		return u.preferred
+	}
+
+	for _, conn := range u.conns {
+		if !conn.idle {
+			return "online"
+		}
+	}
+
+	return "idle"
+}
+
+// update records the status of a user, queuing the change for onChange
+// when notify is set. It is called with p.mu held, the queue being handed to
+// onChange by notify once it is released.
+func (p *Presence) update(userId string, u *userPresence, notify bool) {
+	status := u.effectiveStatus()
+	if status == u.status {
+		return
+	}
+
+	u.status = status
+	if notify {
+		p.pending = append(p.pending, statusChange{userId: userId, status: status})
+	}
+}
+
+// notify wakes up the goroutine calling onChange for the queued changes.
+func (p *Presence) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Connect registers a new active connection of a user.
+func (p *Presence) Connect(userId, connId, preferred string) {
+	p.control(Command{Kind: commandConnect, UserId: userId, ConnId: connId, Status: preferred})
+}
+
+// Disconnect removes a connection. Once the last one is gone, the user is
+// marked offline after PresenceGracePeriod unless they reconnect meanwhile.
+func (p *Presence) Disconnect(userId, connId string) {
+	p.control(Command{Kind: commandDisconnect, UserId: userId, ConnId: connId})
+}
+
+// SetIdle records the activity reported by the client of a connection.
+func (p *Presence) SetIdle(userId, connId string, idle bool) {
+	p.control(Command{Kind: commandIdle, UserId: userId, ConnId: connId, Idle: idle})
+}
+
+// SetPreferred changes the status picked by the user, e.g. "dnd" or "invisible".
+func (p *Presence) SetPreferred(userId, status string) {
+	p.control(Command{Kind: commandPreferred, UserId: userId, Status: status})
+}
+
+// Sync asks the other instances for the connections they hold, so an
+// instance that just started doesn't take their users for offline.
+func (p *Presence) Sync() {
+	p.control(Command{Kind: commandPresenceSync})
+}
+
+// apply runs a presence command sent by any instance.
+func (p *Presence) apply(cmd Command) {
+	switch cmd.Kind {
+	case commandConnect:
+		p.connect(cmd.UserId, cmd.ConnId, cmd.Status, false, cmd.Local)
+	case commandPresenceState:
+		if !cmd.Local {
+			p.connect(cmd.UserId, cmd.ConnId, cmd.Status, cmd.Idle, false)
+		}
+	case commandDisconnect:
+		p.disconnect(cmd.UserId, cmd.ConnId, cmd.Local)
+	case commandIdle:
+		p.setIdle(cmd.UserId, cmd.ConnId, cmd.Idle, cmd.Local)
+	case commandPreferred:
+		p.setPreferred(cmd.UserId, cmd.Status, cmd.Local)
+	case commandPresenceSync:
+		if !cmd.Local {
+			p.announce()
+		}
+	}
+}
+
+func (p *Presence) connect(userId, connId, preferred string, idle, local bool) {
+	defer p.notify()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	u, ok := p.users[userId]
+	if !ok {
+		u = &userPresence{status: "offline", conns: make(map[string]*presenceConn)}
+		p.users[userId] = u
+	}
+
+	if u.offline != nil {
+		u.offline.Stop()
+		u.offline = nil
+	}
+
+	u.preferred = preferred
+	u.conns[connId] = &presenceConn{idle: idle, local: local}
+	p.update(userId, u, local)
+}
+
+func (p *Presence) disconnect(userId, connId string, local bool) {
+	defer p.notify()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	u, ok := p.users[userId]
+	if !ok {
+		return
+	}
+
+	delete(u.conns, connId)
+	if len(u.conns) > 0 {
+		p.update(userId, u, local)
+		return
+	}
+
+	if u.offline != nil {
+		u.offline.Stop()
+	}
+	u.offline = time.AfterFunc(PresenceGracePeriod, func() {
+		defer p.notify()
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if p.users[userId] != u || len(u.conns) > 0 {
+			return
+		}
+
+		p.update(userId, u, local)
+		delete(p.users, userId)
+	})
+}
+
+func (p *Presence) setIdle(userId, connId string, idle, local bool) {
+	defer p.notify()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	u, ok := p.users[userId]
+	if !ok {
+		return
+	}
+
+	conn, ok := u.conns[connId]
+	if !ok {
+		return
+	}
+
+	conn.idle = idle
+	p.update(userId, u, local)
+}
+
+func (p *Presence) setPreferred(userId, status string, local bool) {
+	defer p.notify()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	u, ok := p.users[userId]
+	if !ok {
+		return
+	}
+
+	u.preferred = status
+	p.update(userId, u, local)
+}
+
+// announce sends the connections of this instance to the other ones.
+func (p *Presence) announce() {
+	p.mu.Lock()
+	cmds := make([]Command, 0)
+	for userId, u := range p.users {
+		for connId, conn := range u.conns {
+			if conn.local {
+				cmds = append(cmds, Command{
+					Kind:   commandPresenceState,
+					UserId: userId,
+					ConnId: connId,
+					Status: u.preferred,
+					Idle:   conn.idle,
+				})
+			}
+		}
+	}
+	p.mu.Unlock()
+
+	for _, cmd := range cmds {
+		p.control(cmd)
+	}
+}
+
+// Status returns the status currently shown for a user.
+func (p *Presence) Status(userId string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if u, ok := p.users[userId]; ok {
+		return u.status
+	}
+
+	return "offline"
+}
@@ -0,0 +1,432 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"goback/internal/utils"
+	"goback/proto/protoMess"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/lxzan/event_emitter"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+// Broker fans events out to the clients subscribed to a topic. Each client
+// is also subscribed to the topic of its user, see userTopic, which is how
+// direct sends reach every device of a user.
+//
+// Subscriptions are local to an instance. Operations that concern all the
+// clients of a user, wherever they are connected, go through Control.
+type Broker interface {
+	Subscribe(topic string, client *Client)
+	Unsubscribe(topic string, client *Client)
+	UnsubscribeAll(client *Client)
+	// Drop unsubscribes every client from topics, on every instance.
+	Drop(topics ...string)
+	Publish(topic string, ev Event)
+	// Control runs a command on every instance, this one included.
+	Control(cmd Command)
+	// Subscriptions returns the number of local subscribers of each topic.
+	Subscriptions() map[string]int
+	Close() error
+}
+
+// Command is an operation on the clients of a user, run by every instance.
+// Topics of a server are only subscribed to by the clients following it.
+//
+// Presence and typing commands keep the same state on every instance, see
+// Presence and Typing.
+// Local is set on the instance that sent the command, which is the only one
+// reporting the changes it causes.
+type Command struct {
+	Kind      string       `json:"kind"`
+	UserId    string       `json:"user_id"`
+	SessionId string       `json:"session_id,omitempty"`
+	ServerId  string       `json:"server_id,omitempty"`
+	Topics    []string     `json:"topics,omitempty"`
+	ConnId    string       `json:"conn_id,omitempty"`
+	ChannelId string       `json:"channel_id,omitempty"`
+	Status    string       `json:"status,omitempty"`
+	Idle      bool         `json:"idle,omitempty"`
+	Conv      conversation `json:"conv,omitempty"`
+	Local     bool         `json:"-"`
+}
+
+const (
+	CommandSubscribe     = "subscribe"
+	CommandUnsubscribe   = "unsubscribe"
+	CommandRevokeSession = "revoke_session"
+	commandDrop          = "drop"
+
+	commandConnect       = "presence_connect"
+	commandDisconnect    = "presence_disconnect"
+	commandIdle          = "presence_idle"
+	commandPreferred     = "presence_preferred"
+	commandPresenceSync  = "presence_sync"
+	commandPresenceState = "presence_state"
+	commandTypingStart   = "typing_start"
+	commandTypingStop    = "typing_stop"
+)
+
+func userTopic(userId string) string {
+	return "user:" + userId
+}
+
+// NewBroker creates the broker selected by PUBSUB_BACKEND, "memory" by
+// default or "redis" to share events between instances through REDIS_URL.
+func NewBroker(handler func(Command)) Broker {
+	switch os.Getenv("PUBSUB_BACKEND") {
+	case "", "memory":
+		return NewMemoryBroker(handler)
+	case "redis":
+		broker, err := NewRedisBroker(os.Getenv("REDIS_URL"), handler)
+		if err != nil {
+			log.Fatalf("Error connecting to redis: %v", err)
+		}
+		return broker
+	default:
+		log.Fatalf("Unknown PUBSUB_BACKEND %q", os.Getenv("PUBSUB_BACKEND"))
+		return nil
+	}
+}
+
+// MemoryBroker delivers events to the clients of this instance only.
+type MemoryBroker struct {
+	em      *event_emitter.EventEmitter[*Client]
+	handler func(Command)
+
+	// topics is the registry of the clients subscribed to each topic, the
+	// emitter keeps the topics of each client in its metadata.
+	mu     sync.Mutex
+	topics map[string]map[int64]*Client
+}
+
+func NewMemoryBroker(handler func(Command)) *MemoryBroker {
+	return &MemoryBroker{
+		em: event_emitter.New[*Client](&event_emitter.Config{
+			BucketNum:  16,
+			BucketSize: 128,
+		}),
+		handler: handler,
+		topics:  make(map[string]map[int64]*Client),
+	}
+}
+
+func (b *MemoryBroker) Subscribe(topic string, client *Client) {
+	b.subscribe(topic, client)
+}
+
+// subscribe reports whether the topic got its first subscriber.
+func (b *MemoryBroker) subscribe(topic string, client *Client) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	clients, ok := b.topics[topic]
+	if !ok {
+		clients = make(map[int64]*Client)
+		b.topics[topic] = clients
+	}
+	clients[client.GetSubscriberID()] = client
+
+	b.em.Subscribe(client, topic, func(subscriber *Client, msg any) {
+		subscriber.deliver(topic, msg.(Event))
+	})
+
+	return !ok
+}
+
+func (b *MemoryBroker) Unsubscribe(topic string, client *Client) {
+	b.unsubscribe(topic, client)
+}
+
+// unsubscribe reports whether the topic lost its last subscriber.
+func (b *MemoryBroker) unsubscribe(topic string, client *Client) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.remove(topic, client)
+}
+
+func (b *MemoryBroker) remove(topic string, client *Client) bool {
+	b.em.UnSubscribe(client, topic)
+
+	clients, ok := b.topics[topic]
+	if !ok {
+		return false
+	}
+
+	delete(clients, client.GetSubscriberID())
+	if len(clients) > 0 {
+		return false
+	}
+
+	delete(b.topics, topic)
+	return true
+}
+
+func (b *MemoryBroker) UnsubscribeAll(client *Client) {
+	b.unsubscribeAll(client)
+}
+
+// unsubscribeAll returns the topics that lost their last subscriber.
+func (b *MemoryBroker) unsubscribeAll(client *Client) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var released []string
+	for _, topic := range b.em.GetTopicsBySubscriber(client) {
+		if b.remove(topic, client) {
+			released = append(released, topic)
+		}
+	}
+
+	return released
+}
+
+func (b *MemoryBroker) Drop(topics ...string) {
+	b.drop(topics)
+}
+
+// drop returns the topics that had subscribers.
+func (b *MemoryBroker) drop(topics []string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var released []string
+	for _, topic := range topics {
+		clients, ok := b.topics[topic]
+		if !ok {
+			continue
+		}
+
+		for _, client := range clients {
+			b.em.UnSubscribe(client, topic)
+		}
+		delete(b.topics, topic)
+		released = append(released, topic)
+	}
+
+	return released
+}
+
+func (b *MemoryBroker) Publish(topic string, ev Event) {
+	b.em.Publish(topic, ev)
+}
+
+func (b *MemoryBroker) Control(cmd Command) {
+	cmd.Local = true
+	b.handler(cmd)
+}
+
+func (b *MemoryBroker) Subscriptions() map[string]int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	counts := make(map[string]int, len(b.topics))
+	for topic, clients := range b.topics {
+		counts[topic] = len(clients)
+	}
+
+	return counts
+}
+
+func (b *MemoryBroker) Close() error {
+	return nil
+}
+
+const (
+	redisTopicPrefix   = "hud:topic:"
+	redisControlTopic  = "hud:control"
+	redisOriginLength  = 16
+	redisChannelBuffer = 4096
+)
+
+// RedisBroker delivers events to the clients of this instance and relays
+// them through redis to the other instances. An instance only listens to
+// the topics its own clients are subscribed to.
+type RedisBroker struct {
+	local  *MemoryBroker
+	rdb    *redis.Client
+	pubsub *redis.PubSub
+	origin string
+
+	// mu serializes subscription changes so a topic is listened to in
+	// redis exactly while it has local subscribers.
+	mu sync.Mutex
+}
+
+func NewRedisBroker(url string, handler func(Command)) (*RedisBroker, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	rdb := redis.NewClient(opts)
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		rdb.Close()
+		return nil, err
+	}
+
+	origin, err := utils.GenerateRandomId(redisOriginLength)
+	if err != nil {
+		rdb.Close()
+		return nil, err
+	}
+
+	b := &RedisBroker{
+		local:  NewMemoryBroker(handler),
+		rdb:    rdb,
+		pubsub: rdb.Subscribe(context.Background(), redisControlTopic),
+		origin: origin,
+	}
+
+	// Wait for the control subscription before anything gets published.
+	if _, err := b.pubsub.Receive(context.Background()); err != nil {
+		b.Close()
+		return nil, err
+	}
+
+	go b.run()
+
+	return b, nil
+}
+
+func (b *RedisBroker) run() {
+	for msg := range b.pubsub.Channel(redis.WithChannelSize(redisChannelBuffer)) {
+		payload := []byte(msg.Payload)
+		if len(payload) <= redisOriginLength || string(payload[:redisOriginLength]) == b.origin {
+			continue
+		}
+		payload = payload[redisOriginLength:]
+
+		if msg.Channel == redisControlTopic {
+			var cmd Command
+			if err := json.Unmarshal(payload, &cmd); err != nil {
+				log.Println(err)
+				continue
+			}
+			if cmd.Kind == commandDrop {
+				b.dropLocal(cmd.Topics)
+			} else {
+				b.local.handler(cmd)
+			}
+			continue
+		}
+
+		wsMess := new(protoMess.WSMessage)
+		if err := proto.Unmarshal(payload, wsMess); err != nil {
+			log.Println(err)
+			continue
+		}
+		b.local.Publish(strings.TrimPrefix(msg.Channel, redisTopicPrefix), NewEvent(wsMess))
+	}
+}
+
+func (b *RedisBroker) Subscribe(topic string, client *Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.local.subscribe(topic, client) {
+		if err := b.pubsub.Subscribe(context.Background(), redisTopicPrefix+topic); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+func (b *RedisBroker) Unsubscribe(topic string, client *Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.local.unsubscribe(topic, client) {
+		b.release(topic)
+	}
+}
+
+func (b *RedisBroker) UnsubscribeAll(client *Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.release(b.local.unsubscribeAll(client)...)
+}
+
+func (b *RedisBroker) Drop(topics ...string) {
+	b.dropLocal(topics)
+	b.publishCommand(Command{Kind: commandDrop, Topics: topics})
+}
+
+func (b *RedisBroker) dropLocal(topics []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.release(b.local.drop(topics)...)
+}
+
+// release stops listening to topics that have no local subscriber left.
+func (b *RedisBroker) release(topics ...string) {
+	if len(topics) == 0 {
+		return
+	}
+
+	channels := make([]string, len(topics))
+	for i, topic := range topics {
+		channels[i] = redisTopicPrefix + topic
+	}
+
+	if err := b.pubsub.Unsubscribe(context.Background(), channels...); err != nil {
+		log.Println(err)
+	}
+}
+
+// Publish relays events as their origin followed by the uncompressed
+// protobuf of the message.
+func (b *RedisBroker) Publish(topic string, ev Event) {
+	b.local.Publish(topic, ev)
+
+	data := ev.Encode(Format{Encoding: EncodingProto, Compression: CompressionNone})
+	payload := make([]byte, 0, redisOriginLength+len(data))
+	payload = append(payload, b.origin...)
+	payload = append(payload, data...)
+
+	if err := b.rdb.Publish(context.Background(), redisTopicPrefix+topic, payload).Err(); err != nil {
+		log.Println(err)
+	}
+}
+
+func (b *RedisBroker) Control(cmd Command) {
+	b.local.Control(cmd)
+	b.publishCommand(cmd)
+}
+
+func (b *RedisBroker) publishCommand(cmd Command) {
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	if err := b.rdb.Publish(context.Background(), redisControlTopic, b.origin+string(data)).Err(); err != nil {
+		log.Println(err)
+	}
+}
+
+func (b *RedisBroker) Subscriptions() map[string]int {
+	return b.local.Subscriptions()
+}
+
+func (b *RedisBroker) Close() error {
+	err := b.pubsub.Close()
+	if cerr := b.rdb.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return fmt.Errorf("an error occured while closing the redis broker: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"goback/proto/protoMess"
+	"os"
+	"testing"
+	"time"
+)
+
+// received waits for a client without socket to buffer its n-th event.
+func received(t *testing.T, client *Client, n uint64) Event {
+	t.Helper()
+
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		client.mu.Lock()
+		seq, f := client.seq, client.replay[n%ReplayBufferSize]
+		client.mu.Unlock()
+
+		if seq >= n {
+			return f
+		}
+	}
+
+	t.Fatalf("event %d was never delivered", n)
+	return Event{}
+}
+
+func TestRedisBroker(t *testing.T) {
+	url := os.Getenv("REDIS_URL")
+	if url == "" {
+		t.Skip("REDIS_URL is not set")
+	}
+
+	commands := make(chan Command, 1)
+	first, err := NewRedisBroker(url, func(Command) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+
+	second, err := NewRedisBroker(url, func(cmd Command) { commands <- cmd })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+
+	client := NewClient("alice", "desktop", "session")
+	second.Subscribe("channels:general", client)
+
+	first.Publish("channels:general", NewEvent(&protoMess.WSMessage{Type: "hello"}))
+	if f := received(t, client, 1); f.Type != "hello" || f.Message.Type != "hello" {
+		t.Fatalf("unexpected event %q", f.Type)
+	}
+
+	second.Publish("channels:general", NewEvent(&protoMess.WSMessage{Type: "local"}))
+	if f := received(t, client, 2); f.Type != "local" {
+		t.Fatalf("unexpected event %q", f.Type)
+	}
+
+	second.Unsubscribe("channels:general", client)
+	first.Publish("channels:general", NewEvent(&protoMess.WSMessage{Type: "ignored"}))
+	first.Control(Command{Kind: CommandSubscribe, UserId: "alice", Topics: []string{"servers:home"}})
+
+	select {
+	case cmd := <-commands:
+		if cmd.Kind != CommandSubscribe || cmd.UserId != "alice" || len(cmd.Topics) != 1 {
+			t.Fatalf("unexpected command %+v", cmd)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the command was never delivered")
+	}
+
+	second.Subscribe("channels:random", client)
+	first.Drop("channels:random")
+	for deadline := time.Now().Add(2 * time.Second); second.Subscriptions()["channels:random"] > 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("the topic was never dropped")
+		}
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.seq != 2 {
+		t.Fatalf("the client received %d events after unsubscribing", client.seq-2)
+	}
+}
@@ -0,0 +1,230 @@
+package server
+
+import (
+	"context"
+	"goback/internal/database"
+	"goback/internal/models"
+	"goback/proto/protoMess"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+// buildReady gathers everything a client needs to render its first screen
+// into the READY event: the user's profile, their servers with categories
+// and channels, their friends with presence, their unread channels and who
+// is in which voice channel.
+func (s *Server) buildReady(user models.User, servers []models.Server) *protoMess.WSMessage {
+	ready := &protoMess.Ready{
+		User: protoUser(user),
+	}
+	ready.User.Email = user.Email
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+
+		friends, err := s.db.GetFriends(user.ID)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		for _, friend := range friends {
+			ready.Friends = append(ready.Friends, protoUser(friend))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		ready.Unreads = s.getUnreads(user.ID, servers)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		voiceStates, err := s.getVoiceStates(servers)
+		if err != nil {
+			log.Println("error on getting voice states", err)
+		}
+		ready.VoiceStates = voiceStates
+	}()
+
+	for _, server := range servers {
+		protoServer := &protoMess.Server{
+			Id:        server.ID,
+			Name:      server.Name,
+			Icon:      server.Icon,
+			Banner:    server.Banner,
+			Roles:     server.Roles,
+			CreatedAt: server.CreatedAt,
+		}
+
+		for _, cat := range server.Categories {
+			category := &protoMess.Category{Name: cat.Name}
+			for _, channel := range cat.Channels {
+				category.Channels = append(category.Channels, &protoMess.Channel{
+					Id:        channel.ID,
+					Name:      channel.Name,
+					Type:      channel.Type,
+					Private:   channel.Private,
+					CreatedAt: channel.CreatedAt,
+				})
+			}
+			protoServer.Categories = append(protoServer.Categories, category)
+		}
+
+		ready.Servers = append(ready.Servers, protoServer)
+	}
+
+	wg.Wait()
+
+	return &protoMess.WSMessage{
+		Type: "ready",
+		Content: &protoMess.WSMessage_Ready{
+			Ready: ready,
+		},
+	}
+}
+
+// getUnreads returns the unread channels of a user. Channels the user has a
+// read state for are counted from it, the others fall back to their unread
+// notifications.
+func (s *Server) getUnreads(userId string, servers []models.Server) []*protoMess.UnreadState {
+	channelServers := make(map[string]string)
+	for _, server := range servers {
+		for _, cat := range server.Categories {
+			for _, channel := range cat.Channels {
+				channelServers[channel.ID] = server.ID
+			}
+		}
+	}
+
+	states, err := s.db.GetReadStates(userId)
+	if err != nil {
+		log.Println(err)
+	}
+
+	var unreads []*protoMess.UnreadState
+	tracked := make(map[string]bool)
+	for _, state := range states {
+		tracked[state.ChannelId] = true
+		if state.UnreadCount == 0 {
+			continue
+		}
+
+		unreads = append(unreads, &protoMess.UnreadState{
+			ChannelId:     state.ChannelId,
+			ServerId:      channelServers[state.ChannelId],
+			Unread:        int32(state.UnreadCount),
+			Mentions:      int32(state.MentionCount),
+			LastMessageId: state.LastMessageId,
+		})
+	}
+
+	notifs, err := s.db.GetUnreadMessageNotifications(userId)
+	if err != nil {
+		log.Println(err)
+	}
+
+	for _, notif := range notifs {
+		// private messages are notified with their author as channel
+		channelId := notif.ChannelId
+		if strings.HasPrefix(channelId, "users:") {
+			channelId = database.DMChannelId(userId, channelId)
+		}
+		if tracked[channelId] {
+			continue
+		}
+
+		unread := &protoMess.UnreadState{
+			ChannelId: channelId,
+			ServerId:  notif.ServerId,
+			Unread:    int32(max(notif.Counter, 1)),
+		}
+		if slices.Contains(notif.Mentions, userId) {
+			unread.Mentions = 1
+		}
+		unreads = append(unreads, unread)
+	}
+
+	return unreads
+}
+
+// getVoiceStates lists the participants of the voice channels of servers.
+func (s *Server) getVoiceStates(servers []models.Server) ([]*protoMess.ParticipantMove, error) {
+	channelServers := make(map[string]string)
+	for _, server := range servers {
+		for _, cat := range server.Categories {
+			for _, channel := range cat.Channels {
+				channelServers[channel.ID] = server.ID
+			}
+		}
+	}
+
+	res, err := s.rtc.ListRooms(context.Background(), &livekit.ListRoomsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var voiceStates []*protoMess.ParticipantMove
+	for _, r := range res.Rooms {
+		serverId, ok := channelServers[r.Name]
+		if !ok || r.NumParticipants == 0 {
+			continue
+		}
+
+		res, err := s.rtc.ListParticipants(context.Background(), &livekit.ListParticipantsRequest{
+			Room: r.Name,
+		})
+		if err != nil {
+			return voiceStates, err
+		}
+
+		for _, participant := range res.Participants {
+			userDb, err := s.db.GetUser(participant.Identity, "", "")
+			if err != nil {
+				return voiceStates, err
+			}
+
+			voiceState := &protoMess.ParticipantMove{
+				User:      protoUser(userDb),
+				UserId:    userDb.ID,
+				ServerId:  serverId,
+				ChannelId: r.Name,
+			}
+			for _, track := range participant.Tracks {
+				if track.Type == livekit.TrackType_AUDIO && track.Muted {
+					voiceState.Muted = true
+				}
+			}
+			voiceStates = append(voiceStates, voiceState)
+		}
+	}
+
+	return voiceStates, nil
+}
+
+// protoUser converts a user to the protobuf sent over websockets, leaving out
+// their email and password.
+func protoUser(user models.User) *protoMess.User {
+	return &protoMess.User{
+		Id:              user.ID,
+		Username:        user.Username,
+		DisplayName:     user.DisplayName,
+		Avatar:          user.Avatar,
+		Banner:          user.Banner,
+		Status:          user.Status,
+		PreferredStatus: user.PreferredStatus,
+		LastSeen:        user.LastSeen,
+		AboutMe:         user.AboutMe,
+		UsernameColor:   user.UsernameColor,
+		CreatedAt:       user.CreatedAt,
+	}
+}
@@ -0,0 +1,17 @@
+package server
+
+import "slices"
+
+var (
+	// adminRoles can change the settings of a server.
+	adminRoles = []string{"owner", "admin"}
+
+	// moderatorRoles can moderate the members and messages of a server.
+	moderatorRoles = []string{"owner", "admin", "moderator"}
+)
+
+func hasAnyRole(roles, wanted []string) bool {
+	return slices.ContainsFunc(roles, func(role string) bool {
+		return slices.Contains(wanted, role)
+	})
+}
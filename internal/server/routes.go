@@ -2,18 +2,23 @@ package server
 
 import (
 	"net/http"
+	"os"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
+// allowedOrigins are the front-ends allowed to call the API and to open a
+// websocket with the session cookie.
+var allowedOrigins = []string{"https://localhost:5173", "http://localhost:5173", "http://localhost:4173", "https://api.hudori.app", "https://hudori.app"}
+
 func (s *Server) RegisterRoutes() http.Handler {
 	e := echo.New()
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	CORSConfig := middleware.CORSConfig{
 		Skipper:          middleware.DefaultSkipper,
-		AllowOrigins:     []string{"https://localhost:5173", "http://localhost:5173", "http://localhost:4173", "https://api.hudori.app", "https://hudori.app"},
+		AllowOrigins:     allowedOrigins,
 		AllowMethods:     []string{http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
 		AllowCredentials: true,
 		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderSetCookie, echo.HeaderCookie, echo.HeaderContentType, echo.HeaderAccept, "X-User-Agent", "X-User-ID"},
@@ -32,9 +37,17 @@ func (s *Server) RegisterRoutes() http.Handler {
 	// auth.GET("/:provider/callback", s.AuthCallbackHandler)
 	// auth.GET("/logout/:provider", s.LogoutHandler)
 
+	e.GET("/internal/metrics/websocket", s.HandlerWebsocketMetrics, s.MetricsAuthMiddleware)
+
+	e.GET("/ws", s.HandlerWebsocket)
 	e.GET("/ws/:userId", s.HandlerWebsocket)
 	api := e.Group("/api/v1", s.SessionAuthMiddleware)
 
+	api.POST("/ws/ticket", s.HandlerWebsocketTicket)
+	if os.Getenv("ENVIRONMENT") == "DEV" {
+		api.GET("/debug/subscriptions", s.HandlerDebugSubscriptions)
+	}
+
 	api.GET("/friends/:userId", s.HandlerFriends)
 	api.POST("/friends/add", s.HandlerAddFriend)
 	api.POST("/friends/accept", s.HandlerAcceptFriend)
@@ -49,23 +62,52 @@ func (s *Server) RegisterRoutes() http.Handler {
 	api.POST("/server/leave", s.HandlerLeaveServer)
 	api.POST("/server/change_icon", s.HandlerChangeServerIcon)
 	api.POST("/server/change_banner", s.HandlerChangeServerBanner)
+	api.POST("/server/edit_history", s.HandlerChangeServerEditHistory)
+	api.POST("/server/mentionable_roles", s.HandlerChangeMentionableRole)
+	api.GET("/server/emojis/:serverId", s.HandlerServerEmojis)
+	api.POST("/server/emojis/create", s.HandlerCreateEmoji)
+	api.POST("/server/emojis/delete", s.HandlerDeleteEmoji)
+
+	api.GET("/search", s.HandlerSearch)
+
+	api.GET("/dms", s.HandlerDMChannels)
+	api.GET("/dms/:dmId/messages", s.HandlerGroupDMMessages)
+	api.POST("/dms/create", s.HandlerCreateGroupDM)
+	api.POST("/dms/add", s.HandlerAddGroupDMUser)
+	api.POST("/dms/remove", s.HandlerRemoveGroupDMUser)
+	api.POST("/dms/update", s.HandlerUpdateGroupDM)
+	api.POST("/dms/change_icon", s.HandlerChangeGroupDMIcon)
 
 	api.GET("/messages/:channelId/private/:userId", s.HandlerPrivateMessages)
 	api.GET("/messages/:channelId", s.HandlerChannelMessages)
 	api.POST("/messages/create", s.HandlerSendMessage)
 	api.PUT("/messages/edit", s.HandlerEditMessage)
 	api.DELETE("/messages/delete", s.HandlerDeleteMessage)
+	api.PUT("/messages/reactions", s.HandlerAddReaction)
+	api.DELETE("/messages/reactions", s.HandlerRemoveReaction)
+	api.GET("/messages/:channelId/:messageId/reactions", s.HandlerReactionUsers)
+	api.PUT("/messages/pins", s.HandlerPinMessage)
+	api.DELETE("/messages/pins", s.HandlerUnpinMessage)
+	api.GET("/messages/:channelId/pins", s.HandlerPins)
+	api.GET("/messages/:channelId/:messageId/history", s.HandlerMessageHistory)
 
 	api.GET("/channels/:channelId/users", s.HandlerUsersIdFromChannel)
 	api.POST("/channels/create", s.HandlerCreateChannel)
 	api.POST("/channels/delete", s.HandlerDeleteChannel)
 	api.POST("/channels/typing", s.HandlerTyping)
+	api.GET("/channels/:channelId/threads", s.HandlerChannelThreads)
+
+	api.POST("/threads/create", s.HandlerCreateThread)
+	api.POST("/threads/join", s.HandlerJoinThread)
+	api.POST("/threads/leave", s.HandlerLeaveThread)
+	api.POST("/threads/update", s.HandlerUpdateThread)
 
 	api.POST("/category/create", s.HandlerCreateCategory)
 	api.POST("/category/delete", s.HandlerDeleteCategory)
 
 	api.GET("/notifications/:userId", s.HandlerNotifications)
 	api.POST("/notifications/message_update", s.HandlerUpdateNotifications)
+	api.POST("/notifications/ack", s.HandlerAck)
 
 	api.POST("/invites/create", s.HandlerCreateInvitation)
 
@@ -0,0 +1,225 @@
+package server
+
+import (
+	"fmt"
+	"goback/internal/models"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 25
+
+	DefaultSearchContext = 1
+	MaxSearchContext     = 3
+)
+
+var searchHas = []string{"image", "link", "reaction"}
+
+// parseSearchQuery splits a search into its words and its filters:
+// from:<user>, in:<channel>, mentions:<user>, has:image|link|reaction,
+// before:<date> and after:<date>, dates being either YYYY-MM-DD or RFC 3339.
+// Users and channels are kept as written, they are resolved by the caller.
+func parseSearchQuery(raw string) (models.SearchQuery, error) {
+	var query models.SearchQuery
+	var words []string
+
+	for _, token := range strings.Fields(raw) {
+		key, value, ok := strings.Cut(token, ":")
+		if !ok || value == "" {
+			words = append(words, token)
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "from":
+			query.From = append(query.From, value)
+		case "in":
+			query.In = append(query.In, value)
+		case "mentions":
+			query.Mentions = append(query.Mentions, value)
+		case "has":
+			value = strings.ToLower(value)
+			if !slices.Contains(searchHas, value) {
+				return query, fmt.Errorf("has: can only be %s", strings.Join(searchHas, ", "))
+			}
+			query.Has = append(query.Has, value)
+		case "before", "after":
+			date, err := parseSearchDate(value)
+			if err != nil {
+				return query, fmt.Errorf("%s: needs a date like 2024-01-31", key)
+			}
+			if strings.ToLower(key) == "before" {
+				query.Before = date
+			} else {
+				query.After = date
+			}
+		default:
+			words = append(words, token)
+		}
+	}
+
+	query.Text = strings.Join(words, " ")
+
+	return query, nil
+}
+
+func parseSearchDate(value string) (string, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", err
+	}
+
+	return t.Format(time.RFC3339), nil
+}
+
+// HandlerSearch searches the messages of a server with ?server_id=, of a
+// channel with ?channel_id= or of the user's conversations with ?dms=true,
+// the search itself being given with ?q=. Each result comes with the
+// messages around it, up to ?context= on each side.
+func (s *Server) HandlerSearch(c echo.Context) error {
+	resp := make(map[string]any)
+
+	query, err := parseSearchQuery(c.QueryParam("q"))
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	userId := sessionUser(c).ID
+	scope := models.SearchScope{
+		ServerId: c.QueryParam("server_id"),
+		DMs:      c.QueryParam("dms") == "true",
+	}
+	scopes := 0
+	for _, set := range []bool{scope.ServerId != "", c.QueryParam("channel_id") != "", scope.DMs} {
+		if set {
+			scopes++
+		}
+	}
+	if scopes != 1 {
+		resp["message"] = "A search needs one of server_id, channel_id and dms."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if channelId := c.QueryParam("channel_id"); channelId != "" {
+		if err := s.checkChannelAccess(userId, channelId, serverChannel); err != nil {
+			resp["message"] = err.Error()
+			return c.JSON(http.StatusForbidden, resp)
+		}
+		scope.ChannelId = "channels:" + channelId
+	}
+
+	channelTable := "channels:"
+	if scope.DMs {
+		channelTable = "dm_channels:"
+	}
+	for i, channel := range query.In {
+		query.In[i] = channelTable + strings.TrimPrefix(channel, channelTable)
+	}
+
+	if query.From, err = s.resolveSearchUsers(query.From); err == nil {
+		query.Mentions, err = s.resolveSearchUsers(query.Mentions)
+	}
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	if query.Text == "" && len(query.From) == 0 && len(query.In) == 0 && len(query.Mentions) == 0 && len(query.Has) == 0 && query.Before == "" && query.After == "" {
+		resp["message"] = "The search is empty."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	query.Limit, err = queryInt(c, "limit", DefaultSearchLimit, MaxSearchLimit)
+	if err == nil && query.Limit == 0 {
+		err = fmt.Errorf("limit must be a positive number")
+	}
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+	query.Offset, err = queryInt(c, "offset", 0, -1)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+	contextSize, err := queryInt(c, "context", DefaultSearchContext, MaxSearchContext)
+	if err != nil {
+		resp["message"] = err.Error()
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	messages, hasMore, err := s.db.SearchMessages(userId, scope, query)
+	if err != nil {
+		resp["message"] = "An error occured when searching messages."
+		return c.JSON(http.StatusBadRequest, resp)
+	}
+
+	results := make([]models.SearchResult, 0, len(messages))
+	for _, message := range messages {
+		result := models.SearchResult{Message: message}
+		if contextSize > 0 {
+			result.Before, result.After, err = s.db.GetMessageContext(userId, message, contextSize)
+			if err != nil {
+				resp["message"] = "An error occured when searching messages."
+				return c.JSON(http.StatusBadRequest, resp)
+			}
+		}
+		results = append(results, result)
+	}
+
+	resp["results"] = results
+	resp["has_more"] = hasMore
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// resolveSearchUsers turns the users of from: and mentions: filters, given
+// by id or by username, into ids.
+func (s *Server) resolveSearchUsers(users []string) ([]string, error) {
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		if strings.HasPrefix(user, "users:") {
+			ids = append(ids, user)
+			continue
+		}
+
+		found, err := s.db.GetUser("", user, "")
+		if err != nil {
+			return nil, fmt.Errorf("no user named %s", user)
+		}
+		ids = append(ids, found.ID)
+	}
+
+	return ids, nil
+}
+
+// queryInt reads a non-negative integer query parameter, capped at limit
+// unless it is negative.
+func queryInt(c echo.Context, name string, fallback, limit int) (int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a positive number", name)
+	}
+	if limit >= 0 {
+		n = min(n, limit)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	query, err := parseSearchQuery("release notes from:alice in:general has:link mentions:users:bob after:2024-01-31 v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query.Text != "release notes v2" {
+		t.Errorf("text = %q", query.Text)
+	}
+	if !slices.Equal(query.From, []string{"alice"}) || !slices.Equal(query.In, []string{"general"}) {
+		t.Errorf("from = %v, in = %v", query.From, query.In)
+	}
+	if !slices.Equal(query.Mentions, []string{"users:bob"}) {
+		t.Errorf("mentions = %v", query.Mentions)
+	}
+	if !slices.Equal(query.Has, []string{"link"}) {
+		t.Errorf("has = %v", query.Has)
+	}
+	if query.After != "2024-01-31T00:00:00Z" || query.Before != "" {
+		t.Errorf("after = %q, before = %q", query.After, query.Before)
+	}
+}
+
+func TestParseSearchQueryKeepsUnknownFilters(t *testing.T) {
+	query, err := parseSearchQuery("see https://example.com or note:this")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query.Text != "see https://example.com or note:this" {
+		t.Errorf("text = %q", query.Text)
+	}
+}
+
+func TestParseSearchQueryErrors(t *testing.T) {
+	for _, raw := range []string{"has:video", "before:yesterday"} {
+		if _, err := parseSearchQuery(raw); err == nil {
+			t.Errorf("%q: expected an error", raw)
+		}
+	}
+}
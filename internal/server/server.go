@@ -17,7 +17,6 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	_ "github.com/joho/godotenv/autoload"
 	lksdk "github.com/livekit/server-sdk-go/v2"
-	"github.com/lxzan/event_emitter"
 )
 
 type Server struct {
@@ -29,11 +28,6 @@ type Server struct {
 	s3   *s3.S3
 }
 
-var globalEmitter = event_emitter.New[*Socket](&event_emitter.Config{
-	BucketNum:  16,
-	BucketSize: 128,
-})
-
 func NewServer() *http.Server {
 	port, err := strconv.Atoi(os.Getenv("PORT"))
 	if err != nil {
@@ -66,10 +60,12 @@ func NewServer() *http.Server {
 		port: port,
 		auth: auth.New(sessionStore),
 		db:   database.New(),
-		ws:   NewWebsocket(),
 		rtc:  NewRTC(),
 		s3:   s3Client,
 	}
+	NewServer.ws = NewWebsocket(NewServer.broadcastStatus, NewServer.publishTyping)
+	NewServer.ws.commands = NewServer.handleCommand
+	go NewServer.archiveThreads()
 	environment := os.Getenv("ENVIRONMENT")
 
 	var tlsConfig *tls.Config
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// TypingTimeout is how long a typing indicator lasts without being
+	// refreshed by the user.
+	TypingTimeout = 8 * time.Second
+
+	// TypingThrottle is the minimum time between two "start" events of a user
+	// in a channel, refreshes in between only push the timeout back.
+	TypingThrottle = 3 * time.Second
+)
+
+// Typing tracks who is typing where. onChange is called with "start" when a
+// user starts typing, which is repeated at most every TypingThrottle, and
+// with "stop" when they stop, send their message or time out.
+//
+// Like Presence, every instance keeps the same state through the broker so
+// the throttle holds wherever the user's requests land. onChange is called
+// by the instance the change was made on, and on timeout by the one that
+// last refreshed the indicator.
+type Typing struct {
+	mu       sync.Mutex
+	typers   map[typingKey]*typer
+	control  func(cmd Command)
+	onChange func(userId, channelId string, conv conversation, status string)
+}
+
+type typingKey struct {
+	userId    string
+	channelId string
+}
+
+type typer struct {
+	conv    conversation
+	sentAt  time.Time
+	timeout *time.Timer
+	local   bool
+}
+
+func NewTyping(control func(cmd Command), onChange func(userId, channelId string, conv conversation, status string)) *Typing {
+	return &Typing{
+		typers:   make(map[typingKey]*typer),
+		control:  control,
+		onChange: onChange,
+	}
+}
+
+// Start marks a user as typing in a conversation until they stop or
+// TypingTimeout elapses.
+func (t *Typing) Start(userId, channelId string, conv conversation) {
+	t.control(Command{Kind: commandTypingStart, UserId: userId, ChannelId: channelId, Conv: conv})
+}
+
+// Stop clears the indicator of a user in a channel, if they were typing.
+func (t *Typing) Stop(userId, channelId string) {
+	t.control(Command{Kind: commandTypingStop, UserId: userId, ChannelId: channelId})
+}
+
+// apply runs a typing command sent by any instance.
+func (t *Typing) apply(cmd Command) {
+	switch cmd.Kind {
+	case commandTypingStart:
+		t.start(cmd.UserId, cmd.ChannelId, cmd.Conv, cmd.Local)
+	case commandTypingStop:
+		t.stop(cmd.UserId, cmd.ChannelId, cmd.Local)
+	}
+}
+
+func (t *Typing) start(userId, channelId string, conv conversation, local bool) {
+	key := typingKey{userId: userId, channelId: channelId}
+
+	t.mu.Lock()
+	tp, ok := t.typers[key]
+	if !ok {
+		tp = &typer{conv: conv}
+		t.typers[key] = tp
+		tp.timeout = time.AfterFunc(TypingTimeout, func() {
+			t.expire(key, tp)
+		})
+	} else {
+		tp.timeout.Reset(TypingTimeout)
+	}
+	tp.local = local
+
+	notify := time.Since(tp.sentAt) >= TypingThrottle
+	if notify {
+		tp.sentAt = time.Now()
+	}
+	t.mu.Unlock()
+
+	if notify && local {
+		t.onChange(userId, channelId, conv, "start")
+	}
+}
+
+func (t *Typing) stop(userId, channelId string, local bool) {
+	key := typingKey{userId: userId, channelId: channelId}
+
+	t.mu.Lock()
+	tp, ok := t.typers[key]
+	if ok {
+		tp.timeout.Stop()
+		delete(t.typers, key)
+	}
+	t.mu.Unlock()
+
+	if ok && local {
+		t.onChange(userId, channelId, tp.conv, "stop")
+	}
+}
+
+func (t *Typing) expire(key typingKey, tp *typer) {
+	t.mu.Lock()
+	current, ok := t.typers[key]
+	expired := ok && current == tp
+	if expired {
+		delete(t.typers, key)
+	}
+	local := tp.local
+	t.mu.Unlock()
+
+	if expired && local {
+		t.onChange(key.userId, key.channelId, tp.conv, "stop")
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTyping(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []string
+		want  []string
+	}{
+		{name: "throttled", steps: []string{"start", "start"}, want: []string{"start"}},
+		{name: "after the throttle", steps: []string{"start", "rewind", "start"}, want: []string{"start", "start"}},
+		{name: "stop", steps: []string{"start", "stop", "start"}, want: []string{"start", "stop", "start"}},
+		{name: "stop without typing", steps: []string{"stop"}, want: []string{}},
+		{name: "expiry", steps: []string{"start", "expire", "stop"}, want: []string{"start", "stop"}},
+		{name: "started elsewhere", steps: []string{"remote start", "start"}, want: []string{}},
+		{name: "stopped elsewhere", steps: []string{"start", "remote stop"}, want: []string{"start"}},
+		{name: "refreshed elsewhere", steps: []string{"start", "remote start", "expire"}, want: []string{"start"}},
+	}
+
+	key := typingKey{userId: "users:alice", channelId: "general"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			var typing *Typing
+			broker := NewMemoryBroker(func(cmd Command) { typing.apply(cmd) })
+			typing = NewTyping(broker.Control, func(userId, channelId string, conv conversation, status string) {
+				got = append(got, status)
+			})
+
+			for _, step := range tt.steps {
+				typing.mu.Lock()
+				tp := typing.typers[key]
+				typing.mu.Unlock()
+
+				switch step {
+				case "start":
+					typing.Start(key.userId, key.channelId, serverChannel)
+				case "stop":
+					typing.Stop(key.userId, key.channelId)
+				case "remote start", "remote stop":
+					kind := commandTypingStart
+					if strings.HasSuffix(step, "stop") {
+						kind = commandTypingStop
+					}
+					typing.apply(Command{Kind: kind, UserId: key.userId, ChannelId: key.channelId})
+				case "rewind":
+					typing.mu.Lock()
+					tp.sentAt = tp.sentAt.Add(-TypingThrottle)
+					typing.mu.Unlock()
+				case "expire":
+					tp.timeout.Stop()
+					typing.expire(key, tp)
+				}
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,13 +1,15 @@
 package server
 
 import (
-	"encoding/json"
-	"fmt"
-	"goback/internal/models"
+	"goback/proto/protoMess"
+	"net/http"
+	"slices"
+	"sync"
 	"time"
 
-	"github.com/lxzan/event_emitter"
 	"github.com/lxzan/gws"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -15,20 +17,128 @@ const (
 	PingWait     = 15 * time.Second
 )
 
+// CloseSessionRevoked is sent when the session a socket was opened with is
+// logged out or expires. Clients must authenticate again before reconnecting.
+const CloseSessionRevoked uint16 = 4001
+
 type Websocket struct {
-	Emitter  *event_emitter.EventEmitter[*Socket]
-	sessions *gws.ConcurrentMap[string, *gws.Conn]
+	broker   Broker
+	sessions *connections
+	presence *Presence
+	typing   *Typing
+	commands func(client *Client, cmd *protoMess.ClientCommand) *protoMess.CommandAck
 }
 
-func NewWebsocket() *Websocket {
-	emitter := event_emitter.New[*Socket](&event_emitter.Config{
-		BucketNum:  16,
-		BucketSize: 128,
-	})
-	return &Websocket{
-		Emitter:  emitter,
-		sessions: gws.NewConcurrentMap[string, *gws.Conn](16),
+// NewWebsocket creates the websocket handler. onStatus and onTyping are
+// called when this instance changes the status of a user or who is typing.
+func NewWebsocket(onStatus func(userId, status string), onTyping func(userId, channelId string, conv conversation, status string)) *Websocket {
+	ws := &Websocket{
+		sessions: &connections{
+			users:   make(map[string]map[string]*Client),
+			clients: make(map[string]*Client),
+		},
+	}
+	ws.presence = NewPresence(ws.control, onStatus)
+	ws.typing = NewTyping(ws.control, onTyping)
+	ws.broker = NewBroker(ws.runCommand)
+	ws.presence.Sync()
+	return ws
+}
+
+func (c *Websocket) control(cmd Command) {
+	c.broker.Control(cmd)
+}
+
+// connections indexes clients by user id and then by connection id, so a
+// user can stay connected from several devices at once, and by client id so
+// they can be resumed.
+type connections struct {
+	mu      sync.RWMutex
+	users   map[string]map[string]*Client
+	clients map[string]*Client
+}
+
+// Store registers a client and returns the one it replaces, if the same
+// device was already connected.
+func (c *connections) Store(client *Client) (*Client, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conns, ok := c.users[client.UserId]
+	if !ok {
+		conns = make(map[string]*Client)
+		c.users[client.UserId] = conns
+	}
+
+	replaced, ok := conns[client.ConnId]
+	if ok && replaced == client {
+		return nil, false
+	} else if ok {
+		delete(c.clients, replaced.ID)
+	}
+
+	conns[client.ConnId] = client
+	c.clients[client.ID] = client
+	return replaced, ok
+}
+
+// Delete removes a client, unless it has already been replaced by a newer
+// one from the same device.
+func (c *connections) Delete(client *Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conns := c.users[client.UserId]
+	if conns[client.ConnId] != client {
+		return
+	}
+
+	delete(conns, client.ConnId)
+	delete(c.clients, client.ID)
+	if len(conns) == 0 {
+		delete(c.users, client.UserId)
+	}
+}
+
+// Load returns every client of a user.
+func (c *connections) Load(userId string) []*Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	conns := make([]*Client, 0, len(c.users[userId]))
+	for _, conn := range c.users[userId] {
+		conns = append(conns, conn)
 	}
+	return conns
+}
+
+// Count returns the number of clients, connected or waiting to be resumed.
+func (c *connections) Count() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.clients)
+}
+
+// All returns every client, connected or waiting to be resumed.
+func (c *connections) All() []*Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	clients := make([]*Client, 0, len(c.clients))
+	for _, client := range c.clients {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// Get returns a client by its id.
+func (c *connections) Get(clientId string) (*Client, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	client, ok := c.clients[clientId]
+	return client, ok
 }
 
 func NewWebsocketUpgrader(handler *Websocket) *gws.Upgrader {
@@ -36,24 +146,166 @@ func NewWebsocketUpgrader(handler *Websocket) *gws.Upgrader {
 		ParallelEnabled:   true,
 		Recovery:          gws.Recovery,
 		PermessageDeflate: gws.PermessageDeflate{Enabled: true},
+		Authorize: func(r *http.Request, _ gws.SessionStorage) bool {
+			return originAllowed(r.Header.Get("Origin"))
+		},
 	})
 }
 
+// originAllowed refuses websockets opened by other sites, which would
+// otherwise be authenticated by the session cookie of the browser. Clients
+// that aren't browsers send no Origin.
+func originAllowed(origin string) bool {
+	return origin == "" || slices.Contains(allowedOrigins, origin)
+}
+
 func (c *Websocket) getMainUserId(socket *gws.Conn) string {
 	userId, _ := socket.Session().Load("userIdMain")
 	return userId.(string)
 }
 
+func (c *Websocket) getClient(socket *gws.Conn) *Client {
+	client, _ := socket.Session().Load("client")
+	return client.(*Client)
+}
+
 func (c *Websocket) OnOpen(socket *gws.Conn) {
-	userId := c.getMainUserId(socket)
-	if conn, ok := c.sessions.Load(userId); ok {
-		conn.WriteClose(1000, []byte("connection has been replaced"))
+	client := c.getClient(socket)
+	if replaced, ok := c.sessions.Store(client); ok {
+		c.drop(replaced, 1000, "connection has been replaced")
+	}
+
+	// A resume that couldn't be honored gets a fresh client, which asks for a
+	// resync since lastSeq is ahead of it.
+	lastSeq, ok := socket.Session().Load("resumeSeq")
+	if !ok {
+		lastSeq = uint64(0)
+	}
+	resumed, _ := socket.Session().Load("resumed")
+	format, _ := socket.Session().Load("format")
+	var ready *Event
+	if ev, ok := socket.Session().Load("ready"); ok {
+		ready = ev.(*Event)
 	}
+	client.attach(socket, format.(Format), lastSeq.(uint64), resumed.(bool), ready)
+
+	preferred, _ := socket.Session().Load("preferredStatus")
+	c.presence.Connect(client.UserId, client.ID, preferred.(string))
 	_ = socket.SetDeadline(time.Now().Add(PingInterval + PingWait))
-	c.sessions.Store(userId, socket)
 }
 
-func (c *Websocket) OnClose(socket *gws.Conn, err error) {}
+func (c *Websocket) OnClose(socket *gws.Conn, err error) {
+	if timer, ok := socket.Session().Load("sessionTimer"); ok {
+		timer.(*time.Timer).Stop()
+	}
+
+	client := c.getClient(socket)
+	detached := client.detach(socket, func() {
+		c.sessions.Delete(client)
+		c.broker.UnsubscribeAll(client)
+	})
+	if detached {
+		c.presence.Disconnect(client.UserId, client.ID)
+	}
+}
+
+// drop closes a client for good, without waiting for it to be resumed.
+func (c *Websocket) drop(client *Client, code uint16, reason string) {
+	client.close(code, reason)
+	c.sessions.Delete(client)
+	c.broker.UnsubscribeAll(client)
+}
+
+// Resume returns the client a reconnecting socket asks to pick up again, as
+// long as it belongs to the same session.
+func (c *Websocket) Resume(clientId, userId, sessionId string) (*Client, bool) {
+	client, ok := c.sessions.Get(clientId)
+	if !ok || client.UserId != userId || client.SessionId != sessionId {
+		return nil, false
+	}
+
+	return client, true
+}
+
+// Connections returns every client of a user connected to this instance,
+// one per device. Clients waiting to be resumed are included so they don't
+// miss anything.
+func (c *Websocket) Connections(userId string) []*Client {
+	return c.sessions.Load(userId)
+}
+
+// Subscribe subscribes a client of this instance to a topic.
+func (c *Websocket) Subscribe(topic string, client *Client) {
+	c.broker.Subscribe(topic, client)
+}
+
+// Unsubscribe unsubscribes a client of this instance from a topic.
+func (c *Websocket) Unsubscribe(topic string, client *Client) {
+	c.broker.Unsubscribe(topic, client)
+}
+
+// Publish sends a message to every client subscribed to a topic.
+func (c *Websocket) Publish(topic string, wsMess *protoMess.WSMessage) {
+	c.broker.Publish(topic, NewEvent(wsMess))
+}
+
+// SendToUser writes a message to all the devices a user is connected from.
+func (c *Websocket) SendToUser(userId string, wsMess *protoMess.WSMessage) {
+	c.broker.Publish(userTopic(userId), NewEvent(wsMess))
+}
+
+// SubscribeUser subscribes all the devices of a user following a server to
+// topics of that server.
+func (c *Websocket) SubscribeUser(userId, serverId string, topics ...string) {
+	c.broker.Control(Command{Kind: CommandSubscribe, UserId: userId, ServerId: serverId, Topics: topics})
+}
+
+// UnsubscribeUser unsubscribes all the devices of a user from topics.
+func (c *Websocket) UnsubscribeUser(userId string, topics ...string) {
+	c.broker.Control(Command{Kind: CommandUnsubscribe, UserId: userId, Topics: topics})
+}
+
+// Drop unsubscribes every client from topics that no longer exist.
+func (c *Websocket) Drop(topics ...string) {
+	c.broker.Drop(topics...)
+}
+
+// CloseSession closes the user's sockets that were opened with the given session.
+func (c *Websocket) CloseSession(userId, sessionId string) {
+	c.broker.Control(Command{Kind: CommandRevokeSession, UserId: userId, SessionId: sessionId})
+}
+
+// runCommand applies a broker command to the clients of this instance.
+func (c *Websocket) runCommand(cmd Command) {
+	switch cmd.Kind {
+	case commandConnect, commandDisconnect, commandIdle, commandPreferred, commandPresenceSync, commandPresenceState:
+		c.presence.apply(cmd)
+		return
+	case commandTypingStart, commandTypingStop:
+		c.typing.apply(cmd)
+		return
+	}
+
+	for _, client := range c.sessions.Load(cmd.UserId) {
+		switch cmd.Kind {
+		case CommandSubscribe:
+			if cmd.ServerId != "" && !client.follows(cmd.ServerId) {
+				continue
+			}
+			for _, topic := range cmd.Topics {
+				c.broker.Subscribe(topic, client)
+			}
+		case CommandUnsubscribe:
+			for _, topic := range cmd.Topics {
+				c.broker.Unsubscribe(topic, client)
+			}
+		case CommandRevokeSession:
+			if client.SessionId == cmd.SessionId {
+				c.drop(client, CloseSessionRevoked, "session revoked")
+			}
+		}
+	}
+}
 
 func (c *Websocket) OnPing(socket *gws.Conn, payload []byte) {
 	_ = socket.SetDeadline(time.Now().Add(PingInterval + PingWait))
@@ -69,41 +321,31 @@ func (c *Websocket) OnMessage(socket *gws.Conn, message *gws.Message) {
 		return
 	}
 
-	var mess models.WSMessage
-	err := json.Unmarshal(message.Data.Bytes(), &mess)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
-
-	obj := mess.Content.(map[string]any)
-	Pub(globalEmitter, obj["serverId"].(string), gws.OpcodeText, message.Data.Bytes())
-}
+	client := c.getClient(socket)
 
-// EMITTER
-type Socket struct{ *gws.Conn }
-
-func (c *Socket) GetSubscriberID() int64 {
-	userId, _ := c.Conn.Session().Load("userIdEmitter")
-	return userId.(int64)
-}
-
-func (c *Socket) GetMetadata() event_emitter.Metadata {
-	return c.Conn.Session()
-}
+	// Commands are JSON in text frames and protobuf in binary ones, whatever
+	// the format the socket receives its events in.
+	cmd := new(protoMess.ClientCommand)
+	var err error
+	if message.Opcode == gws.OpcodeText {
+		err = protojson.Unmarshal(message.Data.Bytes(), cmd)
+	} else {
+		err = proto.Unmarshal(message.Data.Bytes(), cmd)
+	}
 
-func Sub(em *event_emitter.EventEmitter[*Socket], topic string, socket *Socket) {
-	em.Subscribe(socket, topic, func(subscriber *Socket, msg any) {
-		_ = msg.(*gws.Broadcaster).Broadcast(subscriber.Conn)
-	})
-}
+	var ack *protoMess.CommandAck
+	if err != nil {
+		ack = &protoMess.CommandAck{Error: errInvalidCommand.Error()}
+	} else {
+		ack = c.commands(client, cmd)
+	}
 
-func Unsub(em *event_emitter.EventEmitter[*Socket], topic string, socket *Socket) {
-	em.UnSubscribe(socket, topic)
-}
+	wsMess := &protoMess.WSMessage{
+		Type: "ack",
+		Content: &protoMess.WSMessage_Ack{
+			Ack: ack,
+		},
+	}
 
-func Pub(em *event_emitter.EventEmitter[*Socket], topic string, op gws.Opcode, msg []byte) {
-	broadcaster := gws.NewBroadcaster(op, msg)
-	defer broadcaster.Close()
-	em.Publish(topic, broadcaster)
+	client.Send(NewEvent(wsMess))
 }
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"compress/zlib"
 	"crypto/rand"
 	"image"
 	"image/draw"
@@ -64,9 +65,17 @@ func CropImageGIF(i int, frame *image.Paletted, croppedGif *gif.GIF, cropX, crop
 	return nil
 }
 
-func CompressMess(data []byte) []byte {
+func CompressBrotli(data []byte, level int) []byte {
 	var b bytes.Buffer
-	w := brotli.NewWriterLevel(&b, brotli.BestCompression)
+	w := brotli.NewWriterLevel(&b, level)
+	w.Write(data)
+	w.Close()
+	return b.Bytes()
+}
+
+func CompressDeflate(data []byte) []byte {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
 	w.Write(data)
 	w.Close()
 	return b.Bytes()
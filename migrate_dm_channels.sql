@@ -0,0 +1,21 @@
+-- Moves the private messages stored with their recipient as channel
+-- ("channels:<user id>") into dm_channels, run it once after table.sql's
+-- dm_channels definitions.
+FOR $message IN (SELECT id, author, channel_id, created_at FROM messages WHERE record::exists(type::thing("users", record::id(channel_id))) ORDER BY created_at) {
+  LET $other = type::thing("users", record::id($message.channel_id));
+  LET $ids = array::sort([record::id($message.author), record::id($other)]);
+  LET $dm = type::thing("dm_channels", $ids[0] + "_" + $ids[1]);
+
+  IF !record::exists($dm) {
+    CREATE $dm CONTENT {
+      users: [$message.author, $other],
+      created_at: $message.created_at,
+    };
+  };
+
+  UPDATE $message.id SET channel_id = $dm;
+  UPDATE $dm SET last_message_id = $message.id, last_message_at = $message.created_at;
+};
+
+-- read states of private messages were keyed by the other user
+DELETE read_states WHERE record::tb(channel_id) = "users";
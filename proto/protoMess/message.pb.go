@@ -25,17 +25,19 @@ type User struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Email         string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
-	Username      string `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
-	DisplayName   string `protobuf:"bytes,5,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
-	Avatar        string `protobuf:"bytes,6,opt,name=avatar,proto3" json:"avatar,omitempty"`
-	Banner        string `protobuf:"bytes,7,opt,name=banner,proto3" json:"banner,omitempty"`
-	Status        string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
-	AboutMe       string `protobuf:"bytes,9,opt,name=about_me,json=aboutMe,proto3" json:"about_me,omitempty"`
-	UsernameColor string `protobuf:"bytes,10,opt,name=username_color,json=usernameColor,proto3" json:"username_color,omitempty"`
-	CreatedAt     string `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Id              string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email           string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Password        string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Username        string `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	DisplayName     string `protobuf:"bytes,5,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Avatar          string `protobuf:"bytes,6,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Banner          string `protobuf:"bytes,7,opt,name=banner,proto3" json:"banner,omitempty"`
+	Status          string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	AboutMe         string `protobuf:"bytes,9,opt,name=about_me,json=aboutMe,proto3" json:"about_me,omitempty"`
+	UsernameColor   string `protobuf:"bytes,10,opt,name=username_color,json=usernameColor,proto3" json:"username_color,omitempty"`
+	CreatedAt       string `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	PreferredStatus string `protobuf:"bytes,12,opt,name=preferred_status,json=preferredStatus,proto3" json:"preferred_status,omitempty"`
+	LastSeen        string `protobuf:"bytes,13,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
 }
 
 func (x *User) Reset() {
@@ -147,21 +149,42 @@ func (x *User) GetCreatedAt() string {
 	return ""
 }
 
+func (x *User) GetPreferredStatus() string {
+	if x != nil {
+		return x.PreferredStatus
+	}
+	return ""
+}
+
+func (x *User) GetLastSeen() string {
+	if x != nil {
+		return x.LastSeen
+	}
+	return ""
+}
+
 type Message struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Author    *User    `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
-	ChannelId string   `protobuf:"bytes,3,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	Content   string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	Edited    bool     `protobuf:"varint,5,opt,name=edited,proto3" json:"edited,omitempty"`
-	Images    []string `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
-	Mentions  []string `protobuf:"bytes,7,rep,name=mentions,proto3" json:"mentions,omitempty"`
-	Replies   *Reply   `protobuf:"bytes,8,opt,name=replies,proto3" json:"replies,omitempty"`
-	UpdatedAt string   `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	CreatedAt string   `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Id              string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author          *User       `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	ChannelId       string      `protobuf:"bytes,3,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Content         string      `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Edited          bool        `protobuf:"varint,5,opt,name=edited,proto3" json:"edited,omitempty"`
+	Images          []string    `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
+	Mentions        []string    `protobuf:"bytes,7,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	Replies         *Reply      `protobuf:"bytes,8,opt,name=replies,proto3" json:"replies,omitempty"`
+	UpdatedAt       string      `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedAt       string      `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Reactions       []*Reaction `protobuf:"bytes,11,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	Thread          *Thread     `protobuf:"bytes,12,opt,name=thread,proto3" json:"thread,omitempty"`
+	DeletedBy       string      `protobuf:"bytes,13,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
+	MentionRoles    []string    `protobuf:"bytes,14,rep,name=mention_roles,json=mentionRoles,proto3" json:"mention_roles,omitempty"`
+	MentionChannels []string    `protobuf:"bytes,15,rep,name=mention_channels,json=mentionChannels,proto3" json:"mention_channels,omitempty"`
+	MentionEveryone bool        `protobuf:"varint,16,opt,name=mention_everyone,json=mentionEveryone,proto3" json:"mention_everyone,omitempty"`
+	MentionHere     bool        `protobuf:"varint,17,opt,name=mention_here,json=mentionHere,proto3" json:"mention_here,omitempty"`
 }
 
 func (x *Message) Reset() {
@@ -266,102 +289,89 @@ func (x *Message) GetCreatedAt() string {
 	return ""
 }
 
-type Reply struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Author  *User  `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
-	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-}
-
-func (x *Reply) Reset() {
-	*x = Reply{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Message) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
 	}
+	return nil
 }
 
-func (x *Reply) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Message) GetThread() *Thread {
+	if x != nil {
+		return x.Thread
+	}
+	return nil
 }
 
-func (*Reply) ProtoMessage() {}
-
-func (x *Reply) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Message) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use Reply.ProtoReflect.Descriptor instead.
-func (*Reply) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{2}
+func (x *Message) GetMentionRoles() []string {
+	if x != nil {
+		return x.MentionRoles
+	}
+	return nil
 }
 
-func (x *Reply) GetId() string {
+func (x *Message) GetMentionChannels() []string {
 	if x != nil {
-		return x.Id
+		return x.MentionChannels
 	}
-	return ""
+	return nil
 }
 
-func (x *Reply) GetAuthor() *User {
+func (x *Message) GetMentionEveryone() bool {
 	if x != nil {
-		return x.Author
+		return x.MentionEveryone
 	}
-	return nil
+	return false
 }
 
-func (x *Reply) GetContent() string {
+func (x *Message) GetMentionHere() bool {
 	if x != nil {
-		return x.Content
+		return x.MentionHere
 	}
-	return ""
+	return false
 }
 
-type MessageNotif struct {
+type Thread struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Type      string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	UserId    string   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	ChannelId string   `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	ServerId  string   `protobuf:"bytes,5,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	Counter   int32    `protobuf:"varint,6,opt,name=counter,proto3" json:"counter,omitempty"`
-	Mentions  []string `protobuf:"bytes,7,rep,name=mentions,proto3" json:"mentions,omitempty"`
-	CreatedAt string   `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Read      bool     `protobuf:"varint,9,opt,name=read,proto3" json:"read,omitempty"`
+	Id              string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentChannelId string `protobuf:"bytes,3,opt,name=parent_channel_id,json=parentChannelId,proto3" json:"parent_channel_id,omitempty"`
+	ParentMessageId string `protobuf:"bytes,4,opt,name=parent_message_id,json=parentMessageId,proto3" json:"parent_message_id,omitempty"`
+	OwnerId         string `protobuf:"bytes,5,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	MessageCount    int32  `protobuf:"varint,6,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"`
+	LastMessageAt   string `protobuf:"bytes,7,opt,name=last_message_at,json=lastMessageAt,proto3" json:"last_message_at,omitempty"`
+	Archived        bool   `protobuf:"varint,8,opt,name=archived,proto3" json:"archived,omitempty"`
+	AutoArchive     int32  `protobuf:"varint,9,opt,name=auto_archive,json=autoArchive,proto3" json:"auto_archive,omitempty"`
+	CreatedAt       string `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 }
 
-func (x *MessageNotif) Reset() {
-	*x = MessageNotif{}
+func (x *Thread) Reset() {
+	*x = Thread{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[3]
+		mi := &file_message_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MessageNotif) String() string {
+func (x *Thread) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MessageNotif) ProtoMessage() {}
+func (*Thread) ProtoMessage() {}
 
-func (x *MessageNotif) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[3]
+func (x *Thread) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -372,105 +382,109 @@ func (x *MessageNotif) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MessageNotif.ProtoReflect.Descriptor instead.
-func (*MessageNotif) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Thread.ProtoReflect.Descriptor instead.
+func (*Thread) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *MessageNotif) GetId() string {
+func (x *Thread) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *MessageNotif) GetType() string {
+func (x *Thread) GetName() string {
 	if x != nil {
-		return x.Type
+		return x.Name
 	}
 	return ""
 }
 
-func (x *MessageNotif) GetUserId() string {
+func (x *Thread) GetParentChannelId() string {
 	if x != nil {
-		return x.UserId
+		return x.ParentChannelId
 	}
 	return ""
 }
 
-func (x *MessageNotif) GetChannelId() string {
+func (x *Thread) GetParentMessageId() string {
 	if x != nil {
-		return x.ChannelId
+		return x.ParentMessageId
 	}
 	return ""
 }
 
-func (x *MessageNotif) GetServerId() string {
+func (x *Thread) GetOwnerId() string {
 	if x != nil {
-		return x.ServerId
+		return x.OwnerId
 	}
 	return ""
 }
 
-func (x *MessageNotif) GetCounter() int32 {
+func (x *Thread) GetMessageCount() int32 {
 	if x != nil {
-		return x.Counter
+		return x.MessageCount
 	}
 	return 0
 }
 
-func (x *MessageNotif) GetMentions() []string {
+func (x *Thread) GetLastMessageAt() string {
 	if x != nil {
-		return x.Mentions
+		return x.LastMessageAt
 	}
-	return nil
+	return ""
 }
 
-func (x *MessageNotif) GetCreatedAt() string {
+func (x *Thread) GetArchived() bool {
 	if x != nil {
-		return x.CreatedAt
+		return x.Archived
 	}
-	return ""
+	return false
 }
 
-func (x *MessageNotif) GetRead() bool {
+func (x *Thread) GetAutoArchive() int32 {
 	if x != nil {
-		return x.Read
+		return x.AutoArchive
 	}
-	return false
+	return 0
 }
 
-type FriendRequest struct {
+func (x *Thread) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type Reaction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	InitiatorId string `protobuf:"bytes,2,opt,name=initiator_id,json=initiatorId,proto3" json:"initiator_id,omitempty"`
-	Message     string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	RequestId   string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
-	Type        string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
-	UserId      string `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CreatedAt   string `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Emoji   string `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	EmojiId string `protobuf:"bytes,2,opt,name=emoji_id,json=emojiId,proto3" json:"emoji_id,omitempty"`
+	Count   int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Me      bool   `protobuf:"varint,4,opt,name=me,proto3" json:"me,omitempty"`
 }
 
-func (x *FriendRequest) Reset() {
-	*x = FriendRequest{}
+func (x *Reaction) Reset() {
+	*x = Reaction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[4]
+		mi := &file_message_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FriendRequest) String() string {
+func (x *Reaction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FriendRequest) ProtoMessage() {}
+func (*Reaction) ProtoMessage() {}
 
-func (x *FriendRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[4]
+func (x *Reaction) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,90 +495,131 @@ func (x *FriendRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FriendRequest.ProtoReflect.Descriptor instead.
-func (*FriendRequest) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Reaction.ProtoReflect.Descriptor instead.
+func (*Reaction) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *FriendRequest) GetId() string {
+func (x *Reaction) GetEmoji() string {
 	if x != nil {
-		return x.Id
+		return x.Emoji
 	}
 	return ""
 }
 
-func (x *FriendRequest) GetInitiatorId() string {
+func (x *Reaction) GetEmojiId() string {
 	if x != nil {
-		return x.InitiatorId
+		return x.EmojiId
 	}
 	return ""
 }
 
-func (x *FriendRequest) GetMessage() string {
+func (x *Reaction) GetCount() int32 {
 	if x != nil {
-		return x.Message
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-func (x *FriendRequest) GetRequestId() string {
+func (x *Reaction) GetMe() bool {
 	if x != nil {
-		return x.RequestId
+		return x.Me
+	}
+	return false
+}
+
+type MessageReaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	UserId    string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Emoji     string `protobuf:"bytes,4,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	EmojiId   string `protobuf:"bytes,5,opt,name=emoji_id,json=emojiId,proto3" json:"emoji_id,omitempty"`
+}
+
+func (x *MessageReaction) Reset() {
+	*x = MessageReaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageReaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReaction) ProtoMessage() {}
+
+func (x *MessageReaction) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReaction.ProtoReflect.Descriptor instead.
+func (*MessageReaction) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MessageReaction) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
 	}
 	return ""
 }
 
-func (x *FriendRequest) GetType() string {
+func (x *MessageReaction) GetChannelId() string {
 	if x != nil {
-		return x.Type
+		return x.ChannelId
 	}
 	return ""
 }
 
-func (x *FriendRequest) GetUserId() string {
+func (x *MessageReaction) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *FriendRequest) GetCreatedAt() string {
+func (x *MessageReaction) GetEmoji() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Emoji
 	}
 	return ""
 }
 
-type WSMessage struct {
+func (x *MessageReaction) GetEmojiId() string {
+	if x != nil {
+		return x.EmojiId
+	}
+	return ""
+}
+
+type PinsUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	// Types that are assignable to Content:
-	//
-	//	*WSMessage_Mess
-	//	*WSMessage_CreateCategory
-	//	*WSMessage_UserId
-	//	*WSMessage_ServerId
-	//	*WSMessage_Channel
-	//	*WSMessage_Delchannel
-	//	*WSMessage_FriendRequest
-	//	*WSMessage_FriendAccept
-	//	*WSMessage_ChangeStatus
-	//	*WSMessage_JoinServer
-	//	*WSMessage_QuitServer
-	//	*WSMessage_ParticipantMove
-	//	*WSMessage_DeleteCategory
-	//	*WSMessage_ChangeAvatar
-	//	*WSMessage_Typing
-	//	*WSMessage_Notification
-	//	*WSMessage_ServerPic
-	Content isWSMessage_Content `protobuf_oneof:"content"`
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	MessageId string `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Pinned    bool   `protobuf:"varint,3,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	UserId    string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
-func (x *WSMessage) Reset() {
-	*x = WSMessage{}
+func (x *PinsUpdate) Reset() {
+	*x = PinsUpdate{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_message_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -572,13 +627,13 @@ func (x *WSMessage) Reset() {
 	}
 }
 
-func (x *WSMessage) String() string {
+func (x *PinsUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WSMessage) ProtoMessage() {}
+func (*PinsUpdate) ProtoMessage() {}
 
-func (x *WSMessage) ProtoReflect() protoreflect.Message {
+func (x *PinsUpdate) ProtoReflect() protoreflect.Message {
 	mi := &file_message_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -590,277 +645,1871 @@ func (x *WSMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WSMessage.ProtoReflect.Descriptor instead.
-func (*WSMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use PinsUpdate.ProtoReflect.Descriptor instead.
+func (*PinsUpdate) Descriptor() ([]byte, []int) {
 	return file_message_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *WSMessage) GetType() string {
+func (x *PinsUpdate) GetChannelId() string {
 	if x != nil {
-		return x.Type
+		return x.ChannelId
 	}
 	return ""
 }
 
-func (m *WSMessage) GetContent() isWSMessage_Content {
-	if m != nil {
-		return m.Content
-	}
-	return nil
-}
-
-func (x *WSMessage) GetMess() *Message {
-	if x, ok := x.GetContent().(*WSMessage_Mess); ok {
-		return x.Mess
+func (x *PinsUpdate) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
 	}
-	return nil
+	return ""
 }
 
-func (x *WSMessage) GetCreateCategory() *CreateCategory {
-	if x, ok := x.GetContent().(*WSMessage_CreateCategory); ok {
-		return x.CreateCategory
+func (x *PinsUpdate) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
 	}
-	return nil
+	return false
 }
 
-func (x *WSMessage) GetUserId() string {
-	if x, ok := x.GetContent().(*WSMessage_UserId); ok {
+func (x *PinsUpdate) GetUserId() string {
+	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *WSMessage) GetServerId() string {
-	if x, ok := x.GetContent().(*WSMessage_ServerId); ok {
-		return x.ServerId
-	}
-	return ""
+type Reply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author  *User  `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *Reply) Reset() {
+	*x = Reply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reply) ProtoMessage() {}
+
+func (x *Reply) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reply.ProtoReflect.Descriptor instead.
+func (*Reply) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Reply) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reply) GetAuthor() *User {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Reply) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type MessageNotif struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type      string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	UserId    string   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ChannelId string   `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	ServerId  string   `protobuf:"bytes,5,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Counter   int32    `protobuf:"varint,6,opt,name=counter,proto3" json:"counter,omitempty"`
+	Mentions  []string `protobuf:"bytes,7,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	CreatedAt string   `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Read      bool     `protobuf:"varint,9,opt,name=read,proto3" json:"read,omitempty"`
+}
+
+func (x *MessageNotif) Reset() {
+	*x = MessageNotif{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageNotif) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageNotif) ProtoMessage() {}
+
+func (x *MessageNotif) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageNotif.ProtoReflect.Descriptor instead.
+func (*MessageNotif) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MessageNotif) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MessageNotif) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MessageNotif) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MessageNotif) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *MessageNotif) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *MessageNotif) GetCounter() int32 {
+	if x != nil {
+		return x.Counter
+	}
+	return 0
+}
+
+func (x *MessageNotif) GetMentions() []string {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *MessageNotif) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *MessageNotif) GetRead() bool {
+	if x != nil {
+		return x.Read
+	}
+	return false
+}
+
+type FriendRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	InitiatorId string `protobuf:"bytes,2,opt,name=initiator_id,json=initiatorId,proto3" json:"initiator_id,omitempty"`
+	Message     string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	RequestId   string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Type        string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	UserId      string `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt   string `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *FriendRequest) Reset() {
+	*x = FriendRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FriendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRequest) ProtoMessage() {}
+
+func (x *FriendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRequest.ProtoReflect.Descriptor instead.
+func (*FriendRequest) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FriendRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FriendRequest) GetInitiatorId() string {
+	if x != nil {
+		return x.InitiatorId
+	}
+	return ""
+}
+
+func (x *FriendRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FriendRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *FriendRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *FriendRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FriendRequest) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type WSMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Types that are assignable to Content:
+	//
+	//	*WSMessage_Mess
+	//	*WSMessage_CreateCategory
+	//	*WSMessage_UserId
+	//	*WSMessage_ServerId
+	//	*WSMessage_Channel
+	//	*WSMessage_Delchannel
+	//	*WSMessage_FriendRequest
+	//	*WSMessage_FriendAccept
+	//	*WSMessage_ChangeStatus
+	//	*WSMessage_JoinServer
+	//	*WSMessage_QuitServer
+	//	*WSMessage_ParticipantMove
+	//	*WSMessage_DeleteCategory
+	//	*WSMessage_ChangeAvatar
+	//	*WSMessage_Typing
+	//	*WSMessage_Notification
+	//	*WSMessage_ServerPic
+	//	*WSMessage_Hello
+	//	*WSMessage_Ack
+	//	*WSMessage_VoiceState
+	//	*WSMessage_Ready
+	//	*WSMessage_ReadState
+	//	*WSMessage_DmChannel
+	//	*WSMessage_Reaction
+	//	*WSMessage_Thread
+	//	*WSMessage_PinsUpdate
+	Content isWSMessage_Content `protobuf_oneof:"content"`
+}
+
+func (x *WSMessage) Reset() {
+	*x = WSMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WSMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WSMessage) ProtoMessage() {}
+
+func (x *WSMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WSMessage.ProtoReflect.Descriptor instead.
+func (*WSMessage) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WSMessage) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (m *WSMessage) GetContent() isWSMessage_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (x *WSMessage) GetMess() *Message {
+	if x, ok := x.GetContent().(*WSMessage_Mess); ok {
+		return x.Mess
+	}
+	return nil
+}
+
+func (x *WSMessage) GetCreateCategory() *CreateCategory {
+	if x, ok := x.GetContent().(*WSMessage_CreateCategory); ok {
+		return x.CreateCategory
+	}
+	return nil
+}
+
+func (x *WSMessage) GetUserId() string {
+	if x, ok := x.GetContent().(*WSMessage_UserId); ok {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WSMessage) GetServerId() string {
+	if x, ok := x.GetContent().(*WSMessage_ServerId); ok {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *WSMessage) GetChannel() *CreateChannel {
+	if x, ok := x.GetContent().(*WSMessage_Channel); ok {
+		return x.Channel
+	}
+	return nil
+}
+
+func (x *WSMessage) GetDelchannel() *DeleteChannel {
+	if x, ok := x.GetContent().(*WSMessage_Delchannel); ok {
+		return x.Delchannel
+	}
+	return nil
+}
+
+func (x *WSMessage) GetFriendRequest() *FriendRequest {
+	if x, ok := x.GetContent().(*WSMessage_FriendRequest); ok {
+		return x.FriendRequest
+	}
+	return nil
+}
+
+func (x *WSMessage) GetFriendAccept() *User {
+	if x, ok := x.GetContent().(*WSMessage_FriendAccept); ok {
+		return x.FriendAccept
+	}
+	return nil
+}
+
+func (x *WSMessage) GetChangeStatus() *ChangeStatus {
+	if x, ok := x.GetContent().(*WSMessage_ChangeStatus); ok {
+		return x.ChangeStatus
+	}
+	return nil
+}
+
+func (x *WSMessage) GetJoinServer() *JoinServer {
+	if x, ok := x.GetContent().(*WSMessage_JoinServer); ok {
+		return x.JoinServer
+	}
+	return nil
+}
+
+func (x *WSMessage) GetQuitServer() *QuitServer {
+	if x, ok := x.GetContent().(*WSMessage_QuitServer); ok {
+		return x.QuitServer
+	}
+	return nil
+}
+
+func (x *WSMessage) GetParticipantMove() *ParticipantMove {
+	if x, ok := x.GetContent().(*WSMessage_ParticipantMove); ok {
+		return x.ParticipantMove
+	}
+	return nil
+}
+
+func (x *WSMessage) GetDeleteCategory() *DeleteCategory {
+	if x, ok := x.GetContent().(*WSMessage_DeleteCategory); ok {
+		return x.DeleteCategory
+	}
+	return nil
+}
+
+func (x *WSMessage) GetChangeAvatar() *ChangeAvatar {
+	if x, ok := x.GetContent().(*WSMessage_ChangeAvatar); ok {
+		return x.ChangeAvatar
+	}
+	return nil
+}
+
+func (x *WSMessage) GetTyping() *Typing {
+	if x, ok := x.GetContent().(*WSMessage_Typing); ok {
+		return x.Typing
+	}
+	return nil
+}
+
+func (x *WSMessage) GetNotification() *MessageNotif {
+	if x, ok := x.GetContent().(*WSMessage_Notification); ok {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *WSMessage) GetServerPic() *ChangeServerEl {
+	if x, ok := x.GetContent().(*WSMessage_ServerPic); ok {
+		return x.ServerPic
+	}
+	return nil
+}
+
+func (x *WSMessage) GetHello() *Hello {
+	if x, ok := x.GetContent().(*WSMessage_Hello); ok {
+		return x.Hello
+	}
+	return nil
+}
+
+func (x *WSMessage) GetAck() *CommandAck {
+	if x, ok := x.GetContent().(*WSMessage_Ack); ok {
+		return x.Ack
+	}
+	return nil
+}
+
+func (x *WSMessage) GetVoiceState() *ParticipantMove {
+	if x, ok := x.GetContent().(*WSMessage_VoiceState); ok {
+		return x.VoiceState
+	}
+	return nil
+}
+
+func (x *WSMessage) GetReady() *Ready {
+	if x, ok := x.GetContent().(*WSMessage_Ready); ok {
+		return x.Ready
+	}
+	return nil
+}
+
+func (x *WSMessage) GetReadState() *ReadState {
+	if x, ok := x.GetContent().(*WSMessage_ReadState); ok {
+		return x.ReadState
+	}
+	return nil
+}
+
+func (x *WSMessage) GetDmChannel() *DMChannel {
+	if x, ok := x.GetContent().(*WSMessage_DmChannel); ok {
+		return x.DmChannel
+	}
+	return nil
+}
+
+func (x *WSMessage) GetReaction() *MessageReaction {
+	if x, ok := x.GetContent().(*WSMessage_Reaction); ok {
+		return x.Reaction
+	}
+	return nil
+}
+
+func (x *WSMessage) GetThread() *Thread {
+	if x, ok := x.GetContent().(*WSMessage_Thread); ok {
+		return x.Thread
+	}
+	return nil
+}
+
+func (x *WSMessage) GetPinsUpdate() *PinsUpdate {
+	if x, ok := x.GetContent().(*WSMessage_PinsUpdate); ok {
+		return x.PinsUpdate
+	}
+	return nil
+}
+
+type isWSMessage_Content interface {
+	isWSMessage_Content()
+}
+
+type WSMessage_Mess struct {
+	Mess *Message `protobuf:"bytes,2,opt,name=mess,proto3,oneof"`
+}
+
+type WSMessage_CreateCategory struct {
+	CreateCategory *CreateCategory `protobuf:"bytes,3,opt,name=create_category,json=createCategory,proto3,oneof"`
+}
+
+type WSMessage_UserId struct {
+	UserId string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+type WSMessage_ServerId struct {
+	ServerId string `protobuf:"bytes,5,opt,name=server_id,json=serverId,proto3,oneof"`
+}
+
+type WSMessage_Channel struct {
+	Channel *CreateChannel `protobuf:"bytes,6,opt,name=channel,proto3,oneof"`
+}
+
+type WSMessage_Delchannel struct {
+	Delchannel *DeleteChannel `protobuf:"bytes,7,opt,name=delchannel,proto3,oneof"`
+}
+
+type WSMessage_FriendRequest struct {
+	FriendRequest *FriendRequest `protobuf:"bytes,8,opt,name=friend_request,json=friendRequest,proto3,oneof"`
+}
+
+type WSMessage_FriendAccept struct {
+	FriendAccept *User `protobuf:"bytes,9,opt,name=friend_accept,json=friendAccept,proto3,oneof"`
+}
+
+type WSMessage_ChangeStatus struct {
+	ChangeStatus *ChangeStatus `protobuf:"bytes,10,opt,name=change_status,json=changeStatus,proto3,oneof"`
+}
+
+type WSMessage_JoinServer struct {
+	JoinServer *JoinServer `protobuf:"bytes,11,opt,name=join_server,json=joinServer,proto3,oneof"`
+}
+
+type WSMessage_QuitServer struct {
+	QuitServer *QuitServer `protobuf:"bytes,12,opt,name=quit_server,json=quitServer,proto3,oneof"`
+}
+
+type WSMessage_ParticipantMove struct {
+	ParticipantMove *ParticipantMove `protobuf:"bytes,13,opt,name=participant_move,json=participantMove,proto3,oneof"`
+}
+
+type WSMessage_DeleteCategory struct {
+	DeleteCategory *DeleteCategory `protobuf:"bytes,14,opt,name=delete_category,json=deleteCategory,proto3,oneof"`
+}
+
+type WSMessage_ChangeAvatar struct {
+	ChangeAvatar *ChangeAvatar `protobuf:"bytes,15,opt,name=change_avatar,json=changeAvatar,proto3,oneof"`
+}
+
+type WSMessage_Typing struct {
+	Typing *Typing `protobuf:"bytes,16,opt,name=typing,proto3,oneof"`
+}
+
+type WSMessage_Notification struct {
+	Notification *MessageNotif `protobuf:"bytes,17,opt,name=notification,proto3,oneof"`
+}
+
+type WSMessage_ServerPic struct {
+	ServerPic *ChangeServerEl `protobuf:"bytes,18,opt,name=server_pic,json=serverPic,proto3,oneof"`
+}
+
+type WSMessage_Hello struct {
+	Hello *Hello `protobuf:"bytes,19,opt,name=hello,proto3,oneof"`
+}
+
+type WSMessage_Ack struct {
+	Ack *CommandAck `protobuf:"bytes,20,opt,name=ack,proto3,oneof"`
+}
+
+type WSMessage_VoiceState struct {
+	VoiceState *ParticipantMove `protobuf:"bytes,21,opt,name=voice_state,json=voiceState,proto3,oneof"`
+}
+
+type WSMessage_Ready struct {
+	Ready *Ready `protobuf:"bytes,22,opt,name=ready,proto3,oneof"`
+}
+
+type WSMessage_ReadState struct {
+	ReadState *ReadState `protobuf:"bytes,23,opt,name=read_state,json=readState,proto3,oneof"`
+}
+
+type WSMessage_DmChannel struct {
+	DmChannel *DMChannel `protobuf:"bytes,24,opt,name=dm_channel,json=dmChannel,proto3,oneof"`
+}
+
+type WSMessage_Reaction struct {
+	Reaction *MessageReaction `protobuf:"bytes,25,opt,name=reaction,proto3,oneof"`
+}
+
+type WSMessage_Thread struct {
+	Thread *Thread `protobuf:"bytes,26,opt,name=thread,proto3,oneof"`
+}
+
+type WSMessage_PinsUpdate struct {
+	PinsUpdate *PinsUpdate `protobuf:"bytes,27,opt,name=pins_update,json=pinsUpdate,proto3,oneof"`
+}
+
+func (*WSMessage_Mess) isWSMessage_Content() {}
+
+func (*WSMessage_CreateCategory) isWSMessage_Content() {}
+
+func (*WSMessage_UserId) isWSMessage_Content() {}
+
+func (*WSMessage_ServerId) isWSMessage_Content() {}
+
+func (*WSMessage_Channel) isWSMessage_Content() {}
+
+func (*WSMessage_Delchannel) isWSMessage_Content() {}
+
+func (*WSMessage_FriendRequest) isWSMessage_Content() {}
+
+func (*WSMessage_FriendAccept) isWSMessage_Content() {}
+
+func (*WSMessage_ChangeStatus) isWSMessage_Content() {}
+
+func (*WSMessage_JoinServer) isWSMessage_Content() {}
+
+func (*WSMessage_QuitServer) isWSMessage_Content() {}
+
+func (*WSMessage_ParticipantMove) isWSMessage_Content() {}
+
+func (*WSMessage_DeleteCategory) isWSMessage_Content() {}
+
+func (*WSMessage_ChangeAvatar) isWSMessage_Content() {}
+
+func (*WSMessage_Typing) isWSMessage_Content() {}
+
+func (*WSMessage_Notification) isWSMessage_Content() {}
+
+func (*WSMessage_ServerPic) isWSMessage_Content() {}
+
+func (*WSMessage_Hello) isWSMessage_Content() {}
+
+func (*WSMessage_Ack) isWSMessage_Content() {}
+
+func (*WSMessage_VoiceState) isWSMessage_Content() {}
+
+func (*WSMessage_Ready) isWSMessage_Content() {}
+
+func (*WSMessage_ReadState) isWSMessage_Content() {}
+
+func (*WSMessage_DmChannel) isWSMessage_Content() {}
+
+func (*WSMessage_Reaction) isWSMessage_Content() {}
+
+func (*WSMessage_Thread) isWSMessage_Content() {}
+
+func (*WSMessage_PinsUpdate) isWSMessage_Content() {}
+
+type DMChannel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Recipients    []*User `protobuf:"bytes,2,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	LastMessageAt string  `protobuf:"bytes,3,opt,name=last_message_at,json=lastMessageAt,proto3" json:"last_message_at,omitempty"`
+	CreatedAt     string  `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Type          string  `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string  `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	Icon          string  `protobuf:"bytes,7,opt,name=icon,proto3" json:"icon,omitempty"`
+	OwnerId       string  `protobuf:"bytes,8,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+}
+
+func (x *DMChannel) Reset() {
+	*x = DMChannel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DMChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DMChannel) ProtoMessage() {}
+
+func (x *DMChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DMChannel.ProtoReflect.Descriptor instead.
+func (*DMChannel) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DMChannel) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DMChannel) GetRecipients() []*User {
+	if x != nil {
+		return x.Recipients
+	}
+	return nil
+}
+
+func (x *DMChannel) GetLastMessageAt() string {
+	if x != nil {
+		return x.LastMessageAt
+	}
+	return ""
+}
+
+func (x *DMChannel) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *DMChannel) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DMChannel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DMChannel) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *DMChannel) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+type ReadState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChannelId     string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	LastMessageId string `protobuf:"bytes,2,opt,name=last_message_id,json=lastMessageId,proto3" json:"last_message_id,omitempty"`
+	UnreadCount   int32  `protobuf:"varint,3,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	MentionCount  int32  `protobuf:"varint,4,opt,name=mention_count,json=mentionCount,proto3" json:"mention_count,omitempty"`
+}
+
+func (x *ReadState) Reset() {
+	*x = ReadState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadState) ProtoMessage() {}
+
+func (x *ReadState) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadState.ProtoReflect.Descriptor instead.
+func (*ReadState) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReadState) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *ReadState) GetLastMessageId() string {
+	if x != nil {
+		return x.LastMessageId
+	}
+	return ""
+}
+
+func (x *ReadState) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+func (x *ReadState) GetMentionCount() int32 {
+	if x != nil {
+		return x.MentionCount
+	}
+	return 0
+}
+
+type Ready struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User        *User              `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Servers     []*Server          `protobuf:"bytes,2,rep,name=servers,proto3" json:"servers,omitempty"`
+	Friends     []*User            `protobuf:"bytes,3,rep,name=friends,proto3" json:"friends,omitempty"`
+	Unreads     []*UnreadState     `protobuf:"bytes,4,rep,name=unreads,proto3" json:"unreads,omitempty"`
+	VoiceStates []*ParticipantMove `protobuf:"bytes,5,rep,name=voice_states,json=voiceStates,proto3" json:"voice_states,omitempty"`
+}
+
+func (x *Ready) Reset() {
+	*x = Ready{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ready) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ready) ProtoMessage() {}
+
+func (x *Ready) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ready.ProtoReflect.Descriptor instead.
+func (*Ready) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Ready) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Ready) GetServers() []*Server {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *Ready) GetFriends() []*User {
+	if x != nil {
+		return x.Friends
+	}
+	return nil
+}
+
+func (x *Ready) GetUnreads() []*UnreadState {
+	if x != nil {
+		return x.Unreads
+	}
+	return nil
+}
+
+func (x *Ready) GetVoiceStates() []*ParticipantMove {
+	if x != nil {
+		return x.VoiceStates
+	}
+	return nil
+}
+
+type Server struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Icon       string      `protobuf:"bytes,3,opt,name=icon,proto3" json:"icon,omitempty"`
+	Banner     string      `protobuf:"bytes,4,opt,name=banner,proto3" json:"banner,omitempty"`
+	Roles      []string    `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles,omitempty"`
+	Categories []*Category `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
+	CreatedAt  string      `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *Server) Reset() {
+	*x = Server{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Server) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server) ProtoMessage() {}
+
+func (x *Server) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server.ProtoReflect.Descriptor instead.
+func (*Server) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Server) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Server) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Server) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Server) GetBanner() string {
+	if x != nil {
+		return x.Banner
+	}
+	return ""
+}
+
+func (x *Server) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *Server) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Server) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type Category struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Channels []*Channel `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"`
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetChannels() []*Channel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+type UnreadState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChannelId     string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	ServerId      string `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Unread        int32  `protobuf:"varint,3,opt,name=unread,proto3" json:"unread,omitempty"`
+	Mentions      int32  `protobuf:"varint,4,opt,name=mentions,proto3" json:"mentions,omitempty"`
+	LastMessageId string `protobuf:"bytes,5,opt,name=last_message_id,json=lastMessageId,proto3" json:"last_message_id,omitempty"`
+}
+
+func (x *UnreadState) Reset() {
+	*x = UnreadState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnreadState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnreadState) ProtoMessage() {}
+
+func (x *UnreadState) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnreadState.ProtoReflect.Descriptor instead.
+func (*UnreadState) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UnreadState) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *UnreadState) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *UnreadState) GetUnread() int32 {
+	if x != nil {
+		return x.Unread
+	}
+	return 0
+}
+
+func (x *UnreadState) GetMentions() int32 {
+	if x != nil {
+		return x.Mentions
+	}
+	return 0
+}
+
+func (x *UnreadState) GetLastMessageId() string {
+	if x != nil {
+		return x.LastMessageId
+	}
+	return ""
+}
+
+type CreateChannel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId     string   `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Channel      *Channel `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	CategoryName string   `protobuf:"bytes,3,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+}
+
+func (x *CreateChannel) Reset() {
+	*x = CreateChannel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChannel) ProtoMessage() {}
+
+func (x *CreateChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChannel.ProtoReflect.Descriptor instead.
+func (*CreateChannel) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateChannel) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *CreateChannel) GetChannel() *Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return nil
+}
+
+func (x *CreateChannel) GetCategoryName() string {
+	if x != nil {
+		return x.CategoryName
+	}
+	return ""
+}
+
+type DeleteChannel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId     string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	ChannelId    string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	CategoryName string `protobuf:"bytes,3,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+}
+
+func (x *DeleteChannel) Reset() {
+	*x = DeleteChannel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChannel) ProtoMessage() {}
+
+func (x *DeleteChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChannel.ProtoReflect.Descriptor instead.
+func (*DeleteChannel) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteChannel) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *DeleteChannel) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *DeleteChannel) GetCategoryName() string {
+	if x != nil {
+		return x.CategoryName
+	}
+	return ""
+}
+
+type CreateCategory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId     string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	CategoryName string `protobuf:"bytes,2,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+}
+
+func (x *CreateCategory) Reset() {
+	*x = CreateCategory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategory) ProtoMessage() {}
+
+func (x *CreateCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategory.ProtoReflect.Descriptor instead.
+func (*CreateCategory) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateCategory) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *CreateCategory) GetCategoryName() string {
+	if x != nil {
+		return x.CategoryName
+	}
+	return ""
+}
+
+type DeleteCategory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId     string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	CategoryName string `protobuf:"bytes,2,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+}
+
+func (x *DeleteCategory) Reset() {
+	*x = DeleteCategory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategory) ProtoMessage() {}
+
+func (x *DeleteCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategory.ProtoReflect.Descriptor instead.
+func (*DeleteCategory) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteCategory) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *DeleteCategory) GetCategoryName() string {
+	if x != nil {
+		return x.CategoryName
+	}
+	return ""
+}
+
+type ChangeStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *ChangeStatus) Reset() {
+	*x = ChangeStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeStatus) ProtoMessage() {}
+
+func (x *ChangeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeStatus.ProtoReflect.Descriptor instead.
+func (*ChangeStatus) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ChangeStatus) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChangeStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type JoinServer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	User     *User  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+}
+
+func (x *JoinServer) Reset() {
+	*x = JoinServer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinServer) ProtoMessage() {}
+
+func (x *JoinServer) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *WSMessage) GetChannel() *CreateChannel {
-	if x, ok := x.GetContent().(*WSMessage_Channel); ok {
-		return x.Channel
+// Deprecated: Use JoinServer.ProtoReflect.Descriptor instead.
+func (*JoinServer) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *JoinServer) GetServerId() string {
+	if x != nil {
+		return x.ServerId
 	}
-	return nil
+	return ""
 }
 
-func (x *WSMessage) GetDelchannel() *DeleteChannel {
-	if x, ok := x.GetContent().(*WSMessage_Delchannel); ok {
-		return x.Delchannel
+func (x *JoinServer) GetUser() *User {
+	if x != nil {
+		return x.User
 	}
 	return nil
 }
 
-func (x *WSMessage) GetFriendRequest() *FriendRequest {
-	if x, ok := x.GetContent().(*WSMessage_FriendRequest); ok {
-		return x.FriendRequest
+type QuitServer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerId string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	UserId   string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *QuitServer) Reset() {
+	*x = QuitServer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *WSMessage) GetFriendAccept() *User {
-	if x, ok := x.GetContent().(*WSMessage_FriendAccept); ok {
-		return x.FriendAccept
+func (x *QuitServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuitServer) ProtoMessage() {}
+
+func (x *QuitServer) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *WSMessage) GetChangeStatus() *ChangeStatus {
-	if x, ok := x.GetContent().(*WSMessage_ChangeStatus); ok {
-		return x.ChangeStatus
+// Deprecated: Use QuitServer.ProtoReflect.Descriptor instead.
+func (*QuitServer) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *QuitServer) GetServerId() string {
+	if x != nil {
+		return x.ServerId
 	}
-	return nil
+	return ""
 }
 
-func (x *WSMessage) GetJoinServer() *JoinServer {
-	if x, ok := x.GetContent().(*WSMessage_JoinServer); ok {
-		return x.JoinServer
+func (x *QuitServer) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-func (x *WSMessage) GetQuitServer() *QuitServer {
-	if x, ok := x.GetContent().(*WSMessage_QuitServer); ok {
-		return x.QuitServer
+type ParticipantMove struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User      *User  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	UserId    string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServerId  string `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	ChannelId string `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Deafen    bool   `protobuf:"varint,5,opt,name=deafen,proto3" json:"deafen,omitempty"`
+	Muted     bool   `protobuf:"varint,6,opt,name=muted,proto3" json:"muted,omitempty"`
+}
+
+func (x *ParticipantMove) Reset() {
+	*x = ParticipantMove{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *WSMessage) GetParticipantMove() *ParticipantMove {
-	if x, ok := x.GetContent().(*WSMessage_ParticipantMove); ok {
-		return x.ParticipantMove
+func (x *ParticipantMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantMove) ProtoMessage() {}
+
+func (x *ParticipantMove) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *WSMessage) GetDeleteCategory() *DeleteCategory {
-	if x, ok := x.GetContent().(*WSMessage_DeleteCategory); ok {
-		return x.DeleteCategory
+// Deprecated: Use ParticipantMove.ProtoReflect.Descriptor instead.
+func (*ParticipantMove) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ParticipantMove) GetUser() *User {
+	if x != nil {
+		return x.User
 	}
 	return nil
 }
 
-func (x *WSMessage) GetChangeAvatar() *ChangeAvatar {
-	if x, ok := x.GetContent().(*WSMessage_ChangeAvatar); ok {
-		return x.ChangeAvatar
+func (x *ParticipantMove) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-func (x *WSMessage) GetTyping() *Typing {
-	if x, ok := x.GetContent().(*WSMessage_Typing); ok {
-		return x.Typing
+func (x *ParticipantMove) GetServerId() string {
+	if x != nil {
+		return x.ServerId
 	}
-	return nil
+	return ""
 }
 
-func (x *WSMessage) GetNotification() *MessageNotif {
-	if x, ok := x.GetContent().(*WSMessage_Notification); ok {
-		return x.Notification
+func (x *ParticipantMove) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
 	}
-	return nil
+	return ""
 }
 
-func (x *WSMessage) GetServerPic() *ChangeServerEl {
-	if x, ok := x.GetContent().(*WSMessage_ServerPic); ok {
-		return x.ServerPic
+func (x *ParticipantMove) GetDeafen() bool {
+	if x != nil {
+		return x.Deafen
 	}
-	return nil
+	return false
 }
 
-type isWSMessage_Content interface {
-	isWSMessage_Content()
+func (x *ParticipantMove) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
 }
 
-type WSMessage_Mess struct {
-	Mess *Message `protobuf:"bytes,2,opt,name=mess,proto3,oneof"`
+type Channel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type         string  `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Private      bool    `protobuf:"varint,4,opt,name=private,proto3" json:"private,omitempty"`
+	CreatedAt    string  `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Participants []*User `protobuf:"bytes,6,rep,name=participants,proto3" json:"participants,omitempty"`
 }
 
-type WSMessage_CreateCategory struct {
-	CreateCategory *CreateCategory `protobuf:"bytes,3,opt,name=create_category,json=createCategory,proto3,oneof"`
+func (x *Channel) Reset() {
+	*x = Channel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type WSMessage_UserId struct {
-	UserId string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3,oneof"`
+func (x *Channel) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type WSMessage_ServerId struct {
-	ServerId string `protobuf:"bytes,5,opt,name=server_id,json=serverId,proto3,oneof"`
+func (*Channel) ProtoMessage() {}
+
+func (x *Channel) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type WSMessage_Channel struct {
-	Channel *CreateChannel `protobuf:"bytes,6,opt,name=channel,proto3,oneof"`
+// Deprecated: Use Channel.ProtoReflect.Descriptor instead.
+func (*Channel) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{24}
 }
 
-type WSMessage_Delchannel struct {
-	Delchannel *DeleteChannel `protobuf:"bytes,7,opt,name=delchannel,proto3,oneof"`
+func (x *Channel) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-type WSMessage_FriendRequest struct {
-	FriendRequest *FriendRequest `protobuf:"bytes,8,opt,name=friend_request,json=friendRequest,proto3,oneof"`
+func (x *Channel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-type WSMessage_FriendAccept struct {
-	FriendAccept *User `protobuf:"bytes,9,opt,name=friend_accept,json=friendAccept,proto3,oneof"`
+func (x *Channel) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
-type WSMessage_ChangeStatus struct {
-	ChangeStatus *ChangeStatus `protobuf:"bytes,10,opt,name=change_status,json=changeStatus,proto3,oneof"`
+func (x *Channel) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *Channel) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Channel) GetParticipants() []*User {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
 }
 
-type WSMessage_JoinServer struct {
-	JoinServer *JoinServer `protobuf:"bytes,11,opt,name=join_server,json=joinServer,proto3,oneof"`
-}
+type ChangeAvatar struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-type WSMessage_QuitServer struct {
-	QuitServer *QuitServer `protobuf:"bytes,12,opt,name=quit_server,json=quitServer,proto3,oneof"`
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Avatar string `protobuf:"bytes,2,opt,name=avatar,proto3" json:"avatar,omitempty"`
 }
 
-type WSMessage_ParticipantMove struct {
-	ParticipantMove *ParticipantMove `protobuf:"bytes,13,opt,name=participant_move,json=participantMove,proto3,oneof"`
+func (x *ChangeAvatar) Reset() {
+	*x = ChangeAvatar{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_message_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type WSMessage_DeleteCategory struct {
-	DeleteCategory *DeleteCategory `protobuf:"bytes,14,opt,name=delete_category,json=deleteCategory,proto3,oneof"`
+func (x *ChangeAvatar) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type WSMessage_ChangeAvatar struct {
-	ChangeAvatar *ChangeAvatar `protobuf:"bytes,15,opt,name=change_avatar,json=changeAvatar,proto3,oneof"`
-}
+func (*ChangeAvatar) ProtoMessage() {}
 
-type WSMessage_Typing struct {
-	Typing *Typing `protobuf:"bytes,16,opt,name=typing,proto3,oneof"`
+func (x *ChangeAvatar) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type WSMessage_Notification struct {
-	Notification *MessageNotif `protobuf:"bytes,17,opt,name=notification,proto3,oneof"`
+// Deprecated: Use ChangeAvatar.ProtoReflect.Descriptor instead.
+func (*ChangeAvatar) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{25}
 }
 
-type WSMessage_ServerPic struct {
-	ServerPic *ChangeServerEl `protobuf:"bytes,18,opt,name=server_pic,json=serverPic,proto3,oneof"`
+func (x *ChangeAvatar) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (*WSMessage_Mess) isWSMessage_Content() {}
-
-func (*WSMessage_CreateCategory) isWSMessage_Content() {}
-
-func (*WSMessage_UserId) isWSMessage_Content() {}
-
-func (*WSMessage_ServerId) isWSMessage_Content() {}
-
-func (*WSMessage_Channel) isWSMessage_Content() {}
-
-func (*WSMessage_Delchannel) isWSMessage_Content() {}
-
-func (*WSMessage_FriendRequest) isWSMessage_Content() {}
-
-func (*WSMessage_FriendAccept) isWSMessage_Content() {}
-
-func (*WSMessage_ChangeStatus) isWSMessage_Content() {}
-
-func (*WSMessage_JoinServer) isWSMessage_Content() {}
-
-func (*WSMessage_QuitServer) isWSMessage_Content() {}
-
-func (*WSMessage_ParticipantMove) isWSMessage_Content() {}
-
-func (*WSMessage_DeleteCategory) isWSMessage_Content() {}
-
-func (*WSMessage_ChangeAvatar) isWSMessage_Content() {}
-
-func (*WSMessage_Typing) isWSMessage_Content() {}
-
-func (*WSMessage_Notification) isWSMessage_Content() {}
-
-func (*WSMessage_ServerPic) isWSMessage_Content() {}
+func (x *ChangeAvatar) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
 
-type CreateChannel struct {
+type ChangeServerEl struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ServerId     string   `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	Channel      *Channel `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
-	CategoryName string   `protobuf:"bytes,3,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Picture string `protobuf:"bytes,2,opt,name=picture,proto3" json:"picture,omitempty"`
 }
 
-func (x *CreateChannel) Reset() {
-	*x = CreateChannel{}
+func (x *ChangeServerEl) Reset() {
+	*x = ChangeServerEl{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[6]
+		mi := &file_message_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateChannel) String() string {
+func (x *ChangeServerEl) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateChannel) ProtoMessage() {}
+func (*ChangeServerEl) ProtoMessage() {}
 
-func (x *CreateChannel) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[6]
+func (x *ChangeServerEl) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -871,59 +2520,53 @@ func (x *CreateChannel) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateChannel.ProtoReflect.Descriptor instead.
-func (*CreateChannel) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ChangeServerEl.ProtoReflect.Descriptor instead.
+func (*ChangeServerEl) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *CreateChannel) GetServerId() string {
+func (x *ChangeServerEl) GetId() string {
 	if x != nil {
-		return x.ServerId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateChannel) GetChannel() *Channel {
-	if x != nil {
-		return x.Channel
-	}
-	return nil
-}
-
-func (x *CreateChannel) GetCategoryName() string {
+func (x *ChangeServerEl) GetPicture() string {
 	if x != nil {
-		return x.CategoryName
+		return x.Picture
 	}
 	return ""
 }
 
-type DeleteChannel struct {
+type Typing struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ServerId     string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	ChannelId    string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	CategoryName string `protobuf:"bytes,3,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	ChannelId   string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	UserId      string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status      string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-func (x *DeleteChannel) Reset() {
-	*x = DeleteChannel{}
+func (x *Typing) Reset() {
+	*x = Typing{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[7]
+		mi := &file_message_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteChannel) String() string {
+func (x *Typing) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteChannel) ProtoMessage() {}
+func (*Typing) ProtoMessage() {}
 
-func (x *DeleteChannel) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[7]
+func (x *Typing) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -934,58 +2577,66 @@ func (x *DeleteChannel) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteChannel.ProtoReflect.Descriptor instead.
-func (*DeleteChannel) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Typing.ProtoReflect.Descriptor instead.
+func (*Typing) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *DeleteChannel) GetServerId() string {
+func (x *Typing) GetDisplayName() string {
 	if x != nil {
-		return x.ServerId
+		return x.DisplayName
 	}
 	return ""
 }
 
-func (x *DeleteChannel) GetChannelId() string {
+func (x *Typing) GetChannelId() string {
 	if x != nil {
 		return x.ChannelId
 	}
 	return ""
 }
 
-func (x *DeleteChannel) GetCategoryName() string {
+func (x *Typing) GetUserId() string {
 	if x != nil {
-		return x.CategoryName
+		return x.UserId
 	}
 	return ""
 }
 
-type CreateCategory struct {
+func (x *Typing) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type Hello struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ServerId     string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	CategoryName string `protobuf:"bytes,2,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Seq       uint64 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Resumed   bool   `protobuf:"varint,3,opt,name=resumed,proto3" json:"resumed,omitempty"`
 }
 
-func (x *CreateCategory) Reset() {
-	*x = CreateCategory{}
+func (x *Hello) Reset() {
+	*x = Hello{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[8]
+		mi := &file_message_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateCategory) String() string {
+func (x *Hello) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCategory) ProtoMessage() {}
+func (*Hello) ProtoMessage() {}
 
-func (x *CreateCategory) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[8]
+func (x *Hello) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -996,51 +2647,67 @@ func (x *CreateCategory) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCategory.ProtoReflect.Descriptor instead.
-func (*CreateCategory) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Hello.ProtoReflect.Descriptor instead.
+func (*Hello) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *CreateCategory) GetServerId() string {
+func (x *Hello) GetSessionId() string {
 	if x != nil {
-		return x.ServerId
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *CreateCategory) GetCategoryName() string {
+func (x *Hello) GetSeq() uint64 {
 	if x != nil {
-		return x.CategoryName
+		return x.Seq
 	}
-	return ""
+	return 0
 }
 
-type DeleteCategory struct {
+func (x *Hello) GetResumed() bool {
+	if x != nil {
+		return x.Resumed
+	}
+	return false
+}
+
+type ClientCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ServerId     string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	CategoryName string `protobuf:"bytes,2,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
-}
-
-func (x *DeleteCategory) Reset() {
-	*x = DeleteCategory{}
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Types that are assignable to Payload:
+	//
+	//	*ClientCommand_Typing
+	//	*ClientCommand_SendMessage
+	//	*ClientCommand_MarkRead
+	//	*ClientCommand_VoiceState
+	//	*ClientCommand_Presence
+	//	*ClientCommand_Servers
+	//	*ClientCommand_Ack
+	Payload isClientCommand_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *ClientCommand) Reset() {
+	*x = ClientCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[9]
+		mi := &file_message_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteCategory) String() string {
+func (x *ClientCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteCategory) ProtoMessage() {}
+func (*ClientCommand) ProtoMessage() {}
 
-func (x *DeleteCategory) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[9]
+func (x *ClientCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1051,51 +2718,148 @@ func (x *DeleteCategory) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteCategory.ProtoReflect.Descriptor instead.
-func (*DeleteCategory) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ClientCommand.ProtoReflect.Descriptor instead.
+func (*ClientCommand) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ClientCommand) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (m *ClientCommand) GetPayload() isClientCommand_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *ClientCommand) GetTyping() *TypingCommand {
+	if x, ok := x.GetPayload().(*ClientCommand_Typing); ok {
+		return x.Typing
+	}
+	return nil
+}
+
+func (x *ClientCommand) GetSendMessage() *SendMessageCommand {
+	if x, ok := x.GetPayload().(*ClientCommand_SendMessage); ok {
+		return x.SendMessage
+	}
+	return nil
+}
+
+func (x *ClientCommand) GetMarkRead() *MarkReadCommand {
+	if x, ok := x.GetPayload().(*ClientCommand_MarkRead); ok {
+		return x.MarkRead
+	}
+	return nil
+}
+
+func (x *ClientCommand) GetVoiceState() *VoiceStateCommand {
+	if x, ok := x.GetPayload().(*ClientCommand_VoiceState); ok {
+		return x.VoiceState
+	}
+	return nil
+}
+
+func (x *ClientCommand) GetPresence() *PresenceCommand {
+	if x, ok := x.GetPayload().(*ClientCommand_Presence); ok {
+		return x.Presence
+	}
+	return nil
+}
+
+func (x *ClientCommand) GetServers() *ServerSubscriptionCommand {
+	if x, ok := x.GetPayload().(*ClientCommand_Servers); ok {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *ClientCommand) GetAck() *AckCommand {
+	if x, ok := x.GetPayload().(*ClientCommand_Ack); ok {
+		return x.Ack
+	}
+	return nil
+}
+
+type isClientCommand_Payload interface {
+	isClientCommand_Payload()
+}
+
+type ClientCommand_Typing struct {
+	Typing *TypingCommand `protobuf:"bytes,2,opt,name=typing,proto3,oneof"`
+}
+
+type ClientCommand_SendMessage struct {
+	SendMessage *SendMessageCommand `protobuf:"bytes,3,opt,name=send_message,json=sendMessage,proto3,oneof"`
+}
+
+type ClientCommand_MarkRead struct {
+	MarkRead *MarkReadCommand `protobuf:"bytes,4,opt,name=mark_read,json=markRead,proto3,oneof"`
+}
+
+type ClientCommand_VoiceState struct {
+	VoiceState *VoiceStateCommand `protobuf:"bytes,5,opt,name=voice_state,json=voiceState,proto3,oneof"`
 }
 
-func (x *DeleteCategory) GetServerId() string {
-	if x != nil {
-		return x.ServerId
-	}
-	return ""
+type ClientCommand_Presence struct {
+	Presence *PresenceCommand `protobuf:"bytes,6,opt,name=presence,proto3,oneof"`
 }
 
-func (x *DeleteCategory) GetCategoryName() string {
-	if x != nil {
-		return x.CategoryName
-	}
-	return ""
+type ClientCommand_Servers struct {
+	Servers *ServerSubscriptionCommand `protobuf:"bytes,7,opt,name=servers,proto3,oneof"`
 }
 
-type ChangeStatus struct {
+type ClientCommand_Ack struct {
+	Ack *AckCommand `protobuf:"bytes,8,opt,name=ack,proto3,oneof"`
+}
+
+func (*ClientCommand_Typing) isClientCommand_Payload() {}
+
+func (*ClientCommand_SendMessage) isClientCommand_Payload() {}
+
+func (*ClientCommand_MarkRead) isClientCommand_Payload() {}
+
+func (*ClientCommand_VoiceState) isClientCommand_Payload() {}
+
+func (*ClientCommand_Presence) isClientCommand_Payload() {}
+
+func (*ClientCommand_Servers) isClientCommand_Payload() {}
+
+func (*ClientCommand_Ack) isClientCommand_Payload() {}
+
+type AckCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ChannelId      string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	MessageId      string `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	PrivateMessage bool   `protobuf:"varint,3,opt,name=private_message,json=privateMessage,proto3" json:"private_message,omitempty"`
+	GroupMessage   bool   `protobuf:"varint,4,opt,name=group_message,json=groupMessage,proto3" json:"group_message,omitempty"`
 }
 
-func (x *ChangeStatus) Reset() {
-	*x = ChangeStatus{}
+func (x *AckCommand) Reset() {
+	*x = AckCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[10]
+		mi := &file_message_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ChangeStatus) String() string {
+func (x *AckCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangeStatus) ProtoMessage() {}
+func (*AckCommand) ProtoMessage() {}
 
-func (x *ChangeStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[10]
+func (x *AckCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1106,51 +2870,65 @@ func (x *ChangeStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangeStatus.ProtoReflect.Descriptor instead.
-func (*ChangeStatus) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use AckCommand.ProtoReflect.Descriptor instead.
+func (*AckCommand) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ChangeStatus) GetUserId() string {
+func (x *AckCommand) GetChannelId() string {
 	if x != nil {
-		return x.UserId
+		return x.ChannelId
 	}
 	return ""
 }
 
-func (x *ChangeStatus) GetStatus() string {
+func (x *AckCommand) GetMessageId() string {
 	if x != nil {
-		return x.Status
+		return x.MessageId
 	}
 	return ""
 }
 
-type JoinServer struct {
+func (x *AckCommand) GetPrivateMessage() bool {
+	if x != nil {
+		return x.PrivateMessage
+	}
+	return false
+}
+
+func (x *AckCommand) GetGroupMessage() bool {
+	if x != nil {
+		return x.GroupMessage
+	}
+	return false
+}
+
+type ServerSubscriptionCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ServerId string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	User     *User  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Subscribe   []string `protobuf:"bytes,1,rep,name=subscribe,proto3" json:"subscribe,omitempty"`
+	Unsubscribe []string `protobuf:"bytes,2,rep,name=unsubscribe,proto3" json:"unsubscribe,omitempty"`
 }
 
-func (x *JoinServer) Reset() {
-	*x = JoinServer{}
+func (x *ServerSubscriptionCommand) Reset() {
+	*x = ServerSubscriptionCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[11]
+		mi := &file_message_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *JoinServer) String() string {
+func (x *ServerSubscriptionCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinServer) ProtoMessage() {}
+func (*ServerSubscriptionCommand) ProtoMessage() {}
 
-func (x *JoinServer) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[11]
+func (x *ServerSubscriptionCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1161,51 +2939,53 @@ func (x *JoinServer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinServer.ProtoReflect.Descriptor instead.
-func (*JoinServer) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ServerSubscriptionCommand.ProtoReflect.Descriptor instead.
+func (*ServerSubscriptionCommand) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *JoinServer) GetServerId() string {
+func (x *ServerSubscriptionCommand) GetSubscribe() []string {
 	if x != nil {
-		return x.ServerId
+		return x.Subscribe
 	}
-	return ""
+	return nil
 }
 
-func (x *JoinServer) GetUser() *User {
+func (x *ServerSubscriptionCommand) GetUnsubscribe() []string {
 	if x != nil {
-		return x.User
+		return x.Unsubscribe
 	}
 	return nil
 }
 
-type QuitServer struct {
+type TypingCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ServerId string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	UserId   string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ChannelId      string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Status         string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	PrivateMessage bool   `protobuf:"varint,3,opt,name=private_message,json=privateMessage,proto3" json:"private_message,omitempty"`
+	GroupMessage   bool   `protobuf:"varint,4,opt,name=group_message,json=groupMessage,proto3" json:"group_message,omitempty"`
 }
 
-func (x *QuitServer) Reset() {
-	*x = QuitServer{}
+func (x *TypingCommand) Reset() {
+	*x = TypingCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[12]
+		mi := &file_message_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *QuitServer) String() string {
+func (x *TypingCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QuitServer) ProtoMessage() {}
+func (*TypingCommand) ProtoMessage() {}
 
-func (x *QuitServer) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[12]
+func (x *TypingCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1216,55 +2996,70 @@ func (x *QuitServer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QuitServer.ProtoReflect.Descriptor instead.
-func (*QuitServer) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use TypingCommand.ProtoReflect.Descriptor instead.
+func (*TypingCommand) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *QuitServer) GetServerId() string {
+func (x *TypingCommand) GetChannelId() string {
 	if x != nil {
-		return x.ServerId
+		return x.ChannelId
 	}
 	return ""
 }
 
-func (x *QuitServer) GetUserId() string {
+func (x *TypingCommand) GetStatus() string {
 	if x != nil {
-		return x.UserId
+		return x.Status
 	}
 	return ""
 }
 
-type ParticipantMove struct {
+func (x *TypingCommand) GetPrivateMessage() bool {
+	if x != nil {
+		return x.PrivateMessage
+	}
+	return false
+}
+
+func (x *TypingCommand) GetGroupMessage() bool {
+	if x != nil {
+		return x.GroupMessage
+	}
+	return false
+}
+
+type SendMessageCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	User      *User  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	UserId    string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	ServerId  string `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	ChannelId string `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	Deafen    bool   `protobuf:"varint,5,opt,name=deafen,proto3" json:"deafen,omitempty"`
-	Muted     bool   `protobuf:"varint,6,opt,name=muted,proto3" json:"muted,omitempty"`
+	ChannelId      string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	ServerId       string   `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Content        string   `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Reply          string   `protobuf:"bytes,4,opt,name=reply,proto3" json:"reply,omitempty"`
+	Mentions       []string `protobuf:"bytes,5,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	PrivateMessage bool     `protobuf:"varint,6,opt,name=private_message,json=privateMessage,proto3" json:"private_message,omitempty"`
+	GroupMessage   bool     `protobuf:"varint,7,opt,name=group_message,json=groupMessage,proto3" json:"group_message,omitempty"`
 }
 
-func (x *ParticipantMove) Reset() {
-	*x = ParticipantMove{}
+func (x *SendMessageCommand) Reset() {
+	*x = SendMessageCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[13]
+		mi := &file_message_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ParticipantMove) String() string {
+func (x *SendMessageCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ParticipantMove) ProtoMessage() {}
+func (*SendMessageCommand) ProtoMessage() {}
 
-func (x *ParticipantMove) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[13]
+func (x *SendMessageCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1275,83 +3070,85 @@ func (x *ParticipantMove) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ParticipantMove.ProtoReflect.Descriptor instead.
-func (*ParticipantMove) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use SendMessageCommand.ProtoReflect.Descriptor instead.
+func (*SendMessageCommand) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *ParticipantMove) GetUser() *User {
+func (x *SendMessageCommand) GetChannelId() string {
 	if x != nil {
-		return x.User
+		return x.ChannelId
 	}
-	return nil
+	return ""
 }
 
-func (x *ParticipantMove) GetUserId() string {
+func (x *SendMessageCommand) GetServerId() string {
 	if x != nil {
-		return x.UserId
+		return x.ServerId
 	}
 	return ""
 }
 
-func (x *ParticipantMove) GetServerId() string {
+func (x *SendMessageCommand) GetContent() string {
 	if x != nil {
-		return x.ServerId
+		return x.Content
 	}
 	return ""
 }
 
-func (x *ParticipantMove) GetChannelId() string {
+func (x *SendMessageCommand) GetReply() string {
 	if x != nil {
-		return x.ChannelId
+		return x.Reply
 	}
 	return ""
 }
 
-func (x *ParticipantMove) GetDeafen() bool {
+func (x *SendMessageCommand) GetMentions() []string {
 	if x != nil {
-		return x.Deafen
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *SendMessageCommand) GetPrivateMessage() bool {
+	if x != nil {
+		return x.PrivateMessage
 	}
 	return false
 }
 
-func (x *ParticipantMove) GetMuted() bool {
+func (x *SendMessageCommand) GetGroupMessage() bool {
 	if x != nil {
-		return x.Muted
+		return x.GroupMessage
 	}
 	return false
 }
 
-type Channel struct {
+type MarkReadCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Type         string  `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Private      bool    `protobuf:"varint,4,opt,name=private,proto3" json:"private,omitempty"`
-	CreatedAt    string  `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Participants []*User `protobuf:"bytes,6,rep,name=participants,proto3" json:"participants,omitempty"`
+	Channels []string `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
 }
 
-func (x *Channel) Reset() {
-	*x = Channel{}
+func (x *MarkReadCommand) Reset() {
+	*x = MarkReadCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[14]
+		mi := &file_message_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Channel) String() string {
+func (x *MarkReadCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Channel) ProtoMessage() {}
+func (*MarkReadCommand) ProtoMessage() {}
 
-func (x *Channel) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[14]
+func (x *MarkReadCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1362,79 +3159,46 @@ func (x *Channel) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Channel.ProtoReflect.Descriptor instead.
-func (*Channel) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *Channel) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Channel) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Channel) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
-}
-
-func (x *Channel) GetPrivate() bool {
-	if x != nil {
-		return x.Private
-	}
-	return false
-}
-
-func (x *Channel) GetCreatedAt() string {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return ""
+// Deprecated: Use MarkReadCommand.ProtoReflect.Descriptor instead.
+func (*MarkReadCommand) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *Channel) GetParticipants() []*User {
+func (x *MarkReadCommand) GetChannels() []string {
 	if x != nil {
-		return x.Participants
+		return x.Channels
 	}
 	return nil
 }
 
-type ChangeAvatar struct {
+type VoiceStateCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Avatar string `protobuf:"bytes,2,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	ServerId  string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Muted     bool   `protobuf:"varint,3,opt,name=muted,proto3" json:"muted,omitempty"`
+	Deafen    bool   `protobuf:"varint,4,opt,name=deafen,proto3" json:"deafen,omitempty"`
 }
 
-func (x *ChangeAvatar) Reset() {
-	*x = ChangeAvatar{}
+func (x *VoiceStateCommand) Reset() {
+	*x = VoiceStateCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[15]
+		mi := &file_message_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ChangeAvatar) String() string {
+func (x *VoiceStateCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangeAvatar) ProtoMessage() {}
+func (*VoiceStateCommand) ProtoMessage() {}
 
-func (x *ChangeAvatar) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[15]
+func (x *VoiceStateCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1445,51 +3209,64 @@ func (x *ChangeAvatar) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangeAvatar.ProtoReflect.Descriptor instead.
-func (*ChangeAvatar) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use VoiceStateCommand.ProtoReflect.Descriptor instead.
+func (*VoiceStateCommand) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ChangeAvatar) GetUserId() string {
+func (x *VoiceStateCommand) GetServerId() string {
 	if x != nil {
-		return x.UserId
+		return x.ServerId
 	}
 	return ""
 }
 
-func (x *ChangeAvatar) GetAvatar() string {
+func (x *VoiceStateCommand) GetChannelId() string {
 	if x != nil {
-		return x.Avatar
+		return x.ChannelId
 	}
 	return ""
 }
 
-type ChangeServerEl struct {
+func (x *VoiceStateCommand) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+func (x *VoiceStateCommand) GetDeafen() bool {
+	if x != nil {
+		return x.Deafen
+	}
+	return false
+}
+
+type PresenceCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Picture string `protobuf:"bytes,2,opt,name=picture,proto3" json:"picture,omitempty"`
+	Idle bool `protobuf:"varint,1,opt,name=idle,proto3" json:"idle,omitempty"`
 }
 
-func (x *ChangeServerEl) Reset() {
-	*x = ChangeServerEl{}
+func (x *PresenceCommand) Reset() {
+	*x = PresenceCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[16]
+		mi := &file_message_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ChangeServerEl) String() string {
+func (x *PresenceCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangeServerEl) ProtoMessage() {}
+func (*PresenceCommand) ProtoMessage() {}
 
-func (x *ChangeServerEl) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[16]
+func (x *PresenceCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1500,53 +3277,46 @@ func (x *ChangeServerEl) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangeServerEl.ProtoReflect.Descriptor instead.
-func (*ChangeServerEl) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *ChangeServerEl) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use PresenceCommand.ProtoReflect.Descriptor instead.
+func (*PresenceCommand) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *ChangeServerEl) GetPicture() string {
+func (x *PresenceCommand) GetIdle() bool {
 	if x != nil {
-		return x.Picture
+		return x.Idle
 	}
-	return ""
+	return false
 }
 
-type Typing struct {
+type CommandAck struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
-	ChannelId   string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
-	UserId      string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Status      string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	RequestId string   `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Ok        bool     `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Error     string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Message   *Message `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 }
-
-func (x *Typing) Reset() {
-	*x = Typing{}
+
+func (x *CommandAck) Reset() {
+	*x = CommandAck{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_message_proto_msgTypes[17]
+		mi := &file_message_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Typing) String() string {
+func (x *CommandAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Typing) ProtoMessage() {}
+func (*CommandAck) ProtoMessage() {}
 
-func (x *Typing) ProtoReflect() protoreflect.Message {
-	mi := &file_message_proto_msgTypes[17]
+func (x *CommandAck) ProtoReflect() protoreflect.Message {
+	mi := &file_message_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1557,44 +3327,44 @@ func (x *Typing) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Typing.ProtoReflect.Descriptor instead.
-func (*Typing) Descriptor() ([]byte, []int) {
-	return file_message_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use CommandAck.ProtoReflect.Descriptor instead.
+func (*CommandAck) Descriptor() ([]byte, []int) {
+	return file_message_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *Typing) GetDisplayName() string {
+func (x *CommandAck) GetRequestId() string {
 	if x != nil {
-		return x.DisplayName
+		return x.RequestId
 	}
 	return ""
 }
 
-func (x *Typing) GetChannelId() string {
+func (x *CommandAck) GetOk() bool {
 	if x != nil {
-		return x.ChannelId
+		return x.Ok
 	}
-	return ""
+	return false
 }
 
-func (x *Typing) GetUserId() string {
+func (x *CommandAck) GetError() string {
 	if x != nil {
-		return x.UserId
+		return x.Error
 	}
 	return ""
 }
 
-func (x *Typing) GetStatus() string {
+func (x *CommandAck) GetMessage() *Message {
 	if x != nil {
-		return x.Status
+		return x.Message
 	}
-	return ""
+	return nil
 }
 
 var File_message_proto protoreflect.FileDescriptor
 
 var file_message_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x06, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x22, 0xb0, 0x02, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72,
+	0x06, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x22, 0xf8, 0x02, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72,
 	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
 	0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
@@ -1613,198 +3383,452 @@ var file_message_proto_rawDesc = []byte{
 	0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x75, 0x73,
 	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
 	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xab, 0x02, 0x0a, 0x07, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
-	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x64, 0x69, 0x74, 0x65, 0x64, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x64, 0x69, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69,
-	0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x27, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x57, 0x0a, 0x05, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x24, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52,
-	0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x22, 0xf0, 0x01, 0x0a, 0x0c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x74,
-	0x69, 0x66, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1b,
-	0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x61, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
-	0x72, 0x65, 0x61, 0x64, 0x22, 0xc7, 0x01, 0x0a, 0x0d, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61,
-	0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e,
-	0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
-	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xbf,
-	0x07, 0x0a, 0x09, 0x57, 0x53, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x12, 0x25, 0x0a, 0x04, 0x6d, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
-	0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
-	0x00, 0x52, 0x04, 0x6d, 0x65, 0x73, 0x73, 0x12, 0x41, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x48, 0x00, 0x52, 0x0e, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x19, 0x0a, 0x07, 0x75, 0x73,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
-	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x07,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x37, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x63, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x75,
-	0x64, 0x6f, 0x72, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e,
-	0x65, 0x6c, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x12, 0x3e, 0x0a, 0x0e, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72,
-	0x69, 0x2e, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
-	0x00, 0x52, 0x0d, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x33, 0x0a, 0x0d, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x70,
-	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69,
-	0x2e, 0x55, 0x73, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x41,
-	0x63, 0x63, 0x65, 0x70, 0x74, 0x12, 0x3b, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68,
-	0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x35, 0x0a, 0x0b, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69,
-	0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0a, 0x6a,
-	0x6f, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x0b, 0x71, 0x75, 0x69,
-	0x74, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x51, 0x75, 0x69, 0x74, 0x53, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x48, 0x00, 0x52, 0x0a, 0x71, 0x75, 0x69, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x12, 0x44, 0x0a, 0x10, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x5f,
-	0x6d, 0x6f, 0x76, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x68, 0x75, 0x64,
-	0x6f, 0x72, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x4d,
-	0x6f, 0x76, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61,
-	0x6e, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x41, 0x0a, 0x0f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43,
-	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x48, 0x00, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x3b, 0x0a, 0x0d, 0x63, 0x68, 0x61,
-	0x6e, 0x67, 0x65, 0x5f, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x14, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x28, 0x0a, 0x06, 0x74, 0x79, 0x70, 0x69, 0x6e, 0x67,
-	0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e,
-	0x54, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x06, 0x74, 0x79, 0x70, 0x69, 0x6e, 0x67,
-	0x12, 0x3a, 0x0a, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x48, 0x00, 0x52, 0x0c,
-	0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x0a,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x70, 0x69, 0x63, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x45, 0x6c, 0x48, 0x00, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x50, 0x69, 0x63, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x22, 0x7c, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29,
-	0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0f, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x74,
-	0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x70,
-	0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12,
-	0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63,
-	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65,
-	0x22, 0x52, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f,
-	0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x23, 0x0a, 0x0d, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
-	0x4e, 0x61, 0x6d, 0x65, 0x22, 0x52, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61,
-	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65,
+	0x65, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65,
+	0x65, 0x6e, 0x22, 0xc0, 0x04, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24,
+	0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x06, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x65, 0x64, 0x69, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65,
+	0x64, 0x69, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27, 0x0a, 0x07, 0x72, 0x65, 0x70,
+	0x6c, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x68, 0x75, 0x64,
+	0x6f, 0x72, 0x69, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x2e, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0b, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x52, 0x65, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x26, 0x0a, 0x06, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x52, 0x06, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10,
+	0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6d, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x65, 0x76, 0x65, 0x72, 0x79, 0x6f, 0x6e, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0f, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x72, 0x79, 0x6f,
+	0x6e, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65,
+	0x72, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x65, 0x72, 0x65, 0x22, 0xca, 0x02, 0x0a, 0x06, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64,
+	0x12, 0x2a, 0x0a, 0x11, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x61, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x6f, 0x41, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x22, 0x61, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x6d, 0x6f, 0x6a, 0x69, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x02, 0x6d, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x5f,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x49,
+	0x64, 0x22, 0x7b, 0x0a, 0x0a, 0x50, 0x69, 0x6e, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70,
+	0x69, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x57,
+	0x0a, 0x05, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xf0, 0x01, 0x0a, 0x0c, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
+	0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d,
+	0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x61, 0x64, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x72, 0x65, 0x61, 0x64, 0x22, 0xc7, 0x01, 0x0a, 0x0d, 0x46,
+	0x72, 0x69, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0xf1, 0x0a, 0x0a, 0x09, 0x57, 0x53, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x6d, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x65, 0x73, 0x73, 0x12, 0x41, 0x0a,
+	0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x48, 0x00,
+	0x52, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x12, 0x19, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x09, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x75,
+	0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x48, 0x00, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x37, 0x0a,
+	0x0a, 0x64, 0x65, 0x6c, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x3e, 0x0a, 0x0e, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64,
+	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x0d, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64,
+	0x5f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0c, 0x66,
+	0x72, 0x69, 0x65, 0x6e, 0x64, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x12, 0x3b, 0x0a, 0x0d, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x35, 0x0a, 0x0b, 0x6a, 0x6f, 0x69, 0x6e,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x48, 0x00, 0x52, 0x0a, 0x6a, 0x6f, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x35, 0x0a, 0x0b, 0x71, 0x75, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x51, 0x75,
+	0x69, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0a, 0x71, 0x75, 0x69, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x10, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63,
+	0x69, 0x70, 0x61, 0x6e, 0x74, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63,
+	0x69, 0x70, 0x61, 0x6e, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x41, 0x0a, 0x0f,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x48, 0x00, 0x52,
+	0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12,
+	0x3b, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x48, 0x00, 0x52, 0x0c,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x28, 0x0a, 0x06,
+	0x74, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x68,
+	0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x54, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x06,
+	0x74, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x3a, 0x0a, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68,
+	0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x74,
+	0x69, 0x66, 0x48, 0x00, 0x52, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x70, 0x69, 0x63,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x45, 0x6c, 0x48, 0x00,
+	0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x50, 0x69, 0x63, 0x12, 0x25, 0x0a, 0x05, 0x68,
+	0x65, 0x6c, 0x6c, 0x6f, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x68, 0x75, 0x64,
+	0x6f, 0x72, 0x69, 0x2e, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x48, 0x00, 0x52, 0x05, 0x68, 0x65, 0x6c,
+	0x6c, 0x6f, 0x12, 0x26, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x03, 0x61, 0x63, 0x6b, 0x12, 0x3a, 0x0a, 0x0b, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69,
+	0x70, 0x61, 0x6e, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x52,
+	0x65, 0x61, 0x64, 0x79, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x32, 0x0a,
+	0x0a, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x32, 0x0a, 0x0a, 0x64, 0x6d, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x44,
+	0x4d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x09, 0x64, 0x6d, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x35, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x06,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x68,
+	0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x48, 0x00, 0x52, 0x06,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x35, 0x0a, 0x0b, 0x70, 0x69, 0x6e, 0x73, 0x5f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x68, 0x75,
+	0x64, 0x6f, 0x72, 0x69, 0x2e, 0x50, 0x69, 0x6e, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48,
+	0x00, 0x52, 0x0a, 0x70, 0x69, 0x6e, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x09, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xe7, 0x01, 0x0a, 0x09, 0x44, 0x4d, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64,
+	0x6f, 0x72, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c,
+	0x61, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x49, 0x64, 0x22, 0x9a, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12,
+	0x26, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x6e, 0x72, 0x65, 0x61,
+	0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x75,
+	0x6e, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65,
+	0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0c, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0xe6, 0x01, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x20, 0x0a, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x07, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x68,
+	0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x07, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x07, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x52, 0x07, 0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x73, 0x12, 0x2d, 0x0a,
+	0x07, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x07, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x73, 0x12, 0x3a, 0x0a, 0x0c,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74,
+	0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x0b, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0xbf, 0x01, 0x0a, 0x06, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x63, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x61, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x61, 0x6e,
+	0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x0a, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x52,
+	0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4b, 0x0a, 0x08, 0x43, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x68,
+	0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x08, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x22, 0xa5, 0x01, 0x0a, 0x0b, 0x55, 0x6e, 0x72, 0x65,
+	0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
+	0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d,
+	0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x22,
+	0x7c, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x70, 0x0a,
+	0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1b,
+	0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22,
+	0x52, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x4e,
+	0x61, 0x6d, 0x65, 0x22, 0x52, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x3f, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x4b, 0x0a, 0x0a, 0x4a, 0x6f, 0x69, 0x6e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x74, 0x65,
-	0x67, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x3f, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x4b, 0x0a, 0x0a, 0x4a, 0x6f, 0x69,
-	0x6e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72,
-	0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x22, 0x42, 0x0a, 0x0a, 0x51, 0x75, 0x69, 0x74, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xb6, 0x01, 0x0a, 0x0f, 0x50,
-	0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x20,
-	0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68,
-	0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72,
+	0x72, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x04, 0x75, 0x73, 0x65, 0x72, 0x22, 0x42, 0x0a, 0x0a, 0x51, 0x75, 0x69, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64,
 	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x61, 0x66, 0x65, 0x6e, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x61, 0x66, 0x65, 0x6e, 0x12, 0x14, 0x0a,
-	0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x75,
-	0x74, 0x65, 0x64, 0x22, 0xac, 0x01, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61,
-	0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74,
-	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
-	0x12, 0x30, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73,
-	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e,
-	0x74, 0x73, 0x22, 0x3f, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x76, 0x61, 0x74,
-	0x61, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61,
-	0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x76, 0x61,
-	0x74, 0x61, 0x72, 0x22, 0x3a, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x45, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x69, 0x63, 0x74, 0x75, 0x72, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x69, 0x63, 0x74, 0x75, 0x72, 0x65, 0x22,
-	0x7b, 0x0a, 0x06, 0x54, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73,
-	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
-	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75,
-	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
-	0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x0d, 0x5a, 0x0b,
-	0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x4d, 0x65, 0x73, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xb6, 0x01, 0x0a, 0x0f, 0x50, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x20, 0x0a,
+	0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75,
+	0x64, 0x6f, 0x72, 0x69, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12,
+	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x61, 0x66, 0x65, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x61, 0x66, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x6d, 0x75, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x75, 0x74,
+	0x65, 0x64, 0x22, 0xac, 0x01, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x30, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74,
+	0x73, 0x22, 0x3f, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x41, 0x76, 0x61, 0x74, 0x61,
+	0x72, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76,
+	0x61, 0x74, 0x61, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74,
+	0x61, 0x72, 0x22, 0x3a, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x45, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x69, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x69, 0x63, 0x74, 0x75, 0x72, 0x65, 0x22, 0x7b,
+	0x0a, 0x06, 0x54, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x52, 0x0a, 0x05, 0x48,
+	0x65, 0x6c, 0x6c, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x22,
+	0xbf, 0x03, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64,
+	0x12, 0x2f, 0x0a, 0x06, 0x74, 0x79, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x54, 0x79, 0x70, 0x69, 0x6e, 0x67,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x06, 0x74, 0x79, 0x70, 0x69, 0x6e,
+	0x67, 0x12, 0x3f, 0x0a, 0x0c, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69,
+	0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x36, 0x0a, 0x09, 0x6d, 0x61, 0x72, 0x6b, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x4d,
+	0x61, 0x72, 0x6b, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00,
+	0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x61, 0x64, 0x12, 0x3c, 0x0a, 0x0b, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x0a, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x73,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x68, 0x75, 0x64,
+	0x6f, 0x72, 0x69, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x3d, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x26,
+	0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x68, 0x75,
+	0x64, 0x6f, 0x72, 0x69, 0x2e, 0x41, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48,
+	0x00, 0x52, 0x03, 0x61, 0x63, 0x6b, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x22, 0x98, 0x01, 0x0a, 0x0a, 0x41, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x27,
+	0x0a, 0x0f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x5b, 0x0a, 0x19,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x6e, 0x73, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x75, 0x6e,
+	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x22, 0x94, 0x01, 0x0a, 0x0d, 0x54, 0x79,
+	0x70, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x70, 0x72, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0xea, 0x01, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x27, 0x0a, 0x0f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74,
+	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2d, 0x0a,
+	0x0f, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x22, 0x7d, 0x0a, 0x11,
+	0x56, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x75,
+	0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x61, 0x66, 0x65, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x61, 0x66, 0x65, 0x6e, 0x22, 0x25, 0x0a, 0x0f, 0x50,
+	0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x69, 0x64,
+	0x6c, 0x65, 0x22, 0x7c, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x41, 0x63, 0x6b,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12,
+	0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x68, 0x75, 0x64, 0x6f, 0x72, 0x69, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x4d, 0x65, 0x73, 0x73, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1819,55 +3843,102 @@ func file_message_proto_rawDescGZIP() []byte {
 	return file_message_proto_rawDescData
 }
 
-var file_message_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_message_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_message_proto_goTypes = []interface{}{
-	(*User)(nil),            // 0: hudori.User
-	(*Message)(nil),         // 1: hudori.Message
-	(*Reply)(nil),           // 2: hudori.Reply
-	(*MessageNotif)(nil),    // 3: hudori.MessageNotif
-	(*FriendRequest)(nil),   // 4: hudori.FriendRequest
-	(*WSMessage)(nil),       // 5: hudori.WSMessage
-	(*CreateChannel)(nil),   // 6: hudori.CreateChannel
-	(*DeleteChannel)(nil),   // 7: hudori.DeleteChannel
-	(*CreateCategory)(nil),  // 8: hudori.CreateCategory
-	(*DeleteCategory)(nil),  // 9: hudori.DeleteCategory
-	(*ChangeStatus)(nil),    // 10: hudori.ChangeStatus
-	(*JoinServer)(nil),      // 11: hudori.JoinServer
-	(*QuitServer)(nil),      // 12: hudori.QuitServer
-	(*ParticipantMove)(nil), // 13: hudori.ParticipantMove
-	(*Channel)(nil),         // 14: hudori.Channel
-	(*ChangeAvatar)(nil),    // 15: hudori.ChangeAvatar
-	(*ChangeServerEl)(nil),  // 16: hudori.ChangeServerEl
-	(*Typing)(nil),          // 17: hudori.Typing
+	(*User)(nil),                      // 0: hudori.User
+	(*Message)(nil),                   // 1: hudori.Message
+	(*Thread)(nil),                    // 2: hudori.Thread
+	(*Reaction)(nil),                  // 3: hudori.Reaction
+	(*MessageReaction)(nil),           // 4: hudori.MessageReaction
+	(*PinsUpdate)(nil),                // 5: hudori.PinsUpdate
+	(*Reply)(nil),                     // 6: hudori.Reply
+	(*MessageNotif)(nil),              // 7: hudori.MessageNotif
+	(*FriendRequest)(nil),             // 8: hudori.FriendRequest
+	(*WSMessage)(nil),                 // 9: hudori.WSMessage
+	(*DMChannel)(nil),                 // 10: hudori.DMChannel
+	(*ReadState)(nil),                 // 11: hudori.ReadState
+	(*Ready)(nil),                     // 12: hudori.Ready
+	(*Server)(nil),                    // 13: hudori.Server
+	(*Category)(nil),                  // 14: hudori.Category
+	(*UnreadState)(nil),               // 15: hudori.UnreadState
+	(*CreateChannel)(nil),             // 16: hudori.CreateChannel
+	(*DeleteChannel)(nil),             // 17: hudori.DeleteChannel
+	(*CreateCategory)(nil),            // 18: hudori.CreateCategory
+	(*DeleteCategory)(nil),            // 19: hudori.DeleteCategory
+	(*ChangeStatus)(nil),              // 20: hudori.ChangeStatus
+	(*JoinServer)(nil),                // 21: hudori.JoinServer
+	(*QuitServer)(nil),                // 22: hudori.QuitServer
+	(*ParticipantMove)(nil),           // 23: hudori.ParticipantMove
+	(*Channel)(nil),                   // 24: hudori.Channel
+	(*ChangeAvatar)(nil),              // 25: hudori.ChangeAvatar
+	(*ChangeServerEl)(nil),            // 26: hudori.ChangeServerEl
+	(*Typing)(nil),                    // 27: hudori.Typing
+	(*Hello)(nil),                     // 28: hudori.Hello
+	(*ClientCommand)(nil),             // 29: hudori.ClientCommand
+	(*AckCommand)(nil),                // 30: hudori.AckCommand
+	(*ServerSubscriptionCommand)(nil), // 31: hudori.ServerSubscriptionCommand
+	(*TypingCommand)(nil),             // 32: hudori.TypingCommand
+	(*SendMessageCommand)(nil),        // 33: hudori.SendMessageCommand
+	(*MarkReadCommand)(nil),           // 34: hudori.MarkReadCommand
+	(*VoiceStateCommand)(nil),         // 35: hudori.VoiceStateCommand
+	(*PresenceCommand)(nil),           // 36: hudori.PresenceCommand
+	(*CommandAck)(nil),                // 37: hudori.CommandAck
 }
 var file_message_proto_depIdxs = []int32{
 	0,  // 0: hudori.Message.author:type_name -> hudori.User
-	2,  // 1: hudori.Message.replies:type_name -> hudori.Reply
-	0,  // 2: hudori.Reply.author:type_name -> hudori.User
-	1,  // 3: hudori.WSMessage.mess:type_name -> hudori.Message
-	8,  // 4: hudori.WSMessage.create_category:type_name -> hudori.CreateCategory
-	6,  // 5: hudori.WSMessage.channel:type_name -> hudori.CreateChannel
-	7,  // 6: hudori.WSMessage.delchannel:type_name -> hudori.DeleteChannel
-	4,  // 7: hudori.WSMessage.friend_request:type_name -> hudori.FriendRequest
-	0,  // 8: hudori.WSMessage.friend_accept:type_name -> hudori.User
-	10, // 9: hudori.WSMessage.change_status:type_name -> hudori.ChangeStatus
-	11, // 10: hudori.WSMessage.join_server:type_name -> hudori.JoinServer
-	12, // 11: hudori.WSMessage.quit_server:type_name -> hudori.QuitServer
-	13, // 12: hudori.WSMessage.participant_move:type_name -> hudori.ParticipantMove
-	9,  // 13: hudori.WSMessage.delete_category:type_name -> hudori.DeleteCategory
-	15, // 14: hudori.WSMessage.change_avatar:type_name -> hudori.ChangeAvatar
-	17, // 15: hudori.WSMessage.typing:type_name -> hudori.Typing
-	3,  // 16: hudori.WSMessage.notification:type_name -> hudori.MessageNotif
-	16, // 17: hudori.WSMessage.server_pic:type_name -> hudori.ChangeServerEl
-	14, // 18: hudori.CreateChannel.channel:type_name -> hudori.Channel
-	0,  // 19: hudori.JoinServer.user:type_name -> hudori.User
-	0,  // 20: hudori.ParticipantMove.user:type_name -> hudori.User
-	0,  // 21: hudori.Channel.participants:type_name -> hudori.User
-	22, // [22:22] is the sub-list for method output_type
-	22, // [22:22] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	6,  // 1: hudori.Message.replies:type_name -> hudori.Reply
+	3,  // 2: hudori.Message.reactions:type_name -> hudori.Reaction
+	2,  // 3: hudori.Message.thread:type_name -> hudori.Thread
+	0,  // 4: hudori.Reply.author:type_name -> hudori.User
+	1,  // 5: hudori.WSMessage.mess:type_name -> hudori.Message
+	18, // 6: hudori.WSMessage.create_category:type_name -> hudori.CreateCategory
+	16, // 7: hudori.WSMessage.channel:type_name -> hudori.CreateChannel
+	17, // 8: hudori.WSMessage.delchannel:type_name -> hudori.DeleteChannel
+	8,  // 9: hudori.WSMessage.friend_request:type_name -> hudori.FriendRequest
+	0,  // 10: hudori.WSMessage.friend_accept:type_name -> hudori.User
+	20, // 11: hudori.WSMessage.change_status:type_name -> hudori.ChangeStatus
+	21, // 12: hudori.WSMessage.join_server:type_name -> hudori.JoinServer
+	22, // 13: hudori.WSMessage.quit_server:type_name -> hudori.QuitServer
+	23, // 14: hudori.WSMessage.participant_move:type_name -> hudori.ParticipantMove
+	19, // 15: hudori.WSMessage.delete_category:type_name -> hudori.DeleteCategory
+	25, // 16: hudori.WSMessage.change_avatar:type_name -> hudori.ChangeAvatar
+	27, // 17: hudori.WSMessage.typing:type_name -> hudori.Typing
+	7,  // 18: hudori.WSMessage.notification:type_name -> hudori.MessageNotif
+	26, // 19: hudori.WSMessage.server_pic:type_name -> hudori.ChangeServerEl
+	28, // 20: hudori.WSMessage.hello:type_name -> hudori.Hello
+	37, // 21: hudori.WSMessage.ack:type_name -> hudori.CommandAck
+	23, // 22: hudori.WSMessage.voice_state:type_name -> hudori.ParticipantMove
+	12, // 23: hudori.WSMessage.ready:type_name -> hudori.Ready
+	11, // 24: hudori.WSMessage.read_state:type_name -> hudori.ReadState
+	10, // 25: hudori.WSMessage.dm_channel:type_name -> hudori.DMChannel
+	4,  // 26: hudori.WSMessage.reaction:type_name -> hudori.MessageReaction
+	2,  // 27: hudori.WSMessage.thread:type_name -> hudori.Thread
+	5,  // 28: hudori.WSMessage.pins_update:type_name -> hudori.PinsUpdate
+	0,  // 29: hudori.DMChannel.recipients:type_name -> hudori.User
+	0,  // 30: hudori.Ready.user:type_name -> hudori.User
+	13, // 31: hudori.Ready.servers:type_name -> hudori.Server
+	0,  // 32: hudori.Ready.friends:type_name -> hudori.User
+	15, // 33: hudori.Ready.unreads:type_name -> hudori.UnreadState
+	23, // 34: hudori.Ready.voice_states:type_name -> hudori.ParticipantMove
+	14, // 35: hudori.Server.categories:type_name -> hudori.Category
+	24, // 36: hudori.Category.channels:type_name -> hudori.Channel
+	24, // 37: hudori.CreateChannel.channel:type_name -> hudori.Channel
+	0,  // 38: hudori.JoinServer.user:type_name -> hudori.User
+	0,  // 39: hudori.ParticipantMove.user:type_name -> hudori.User
+	0,  // 40: hudori.Channel.participants:type_name -> hudori.User
+	32, // 41: hudori.ClientCommand.typing:type_name -> hudori.TypingCommand
+	33, // 42: hudori.ClientCommand.send_message:type_name -> hudori.SendMessageCommand
+	34, // 43: hudori.ClientCommand.mark_read:type_name -> hudori.MarkReadCommand
+	35, // 44: hudori.ClientCommand.voice_state:type_name -> hudori.VoiceStateCommand
+	36, // 45: hudori.ClientCommand.presence:type_name -> hudori.PresenceCommand
+	31, // 46: hudori.ClientCommand.servers:type_name -> hudori.ServerSubscriptionCommand
+	30, // 47: hudori.ClientCommand.ack:type_name -> hudori.AckCommand
+	1,  // 48: hudori.CommandAck.message:type_name -> hudori.Message
+	49, // [49:49] is the sub-list for method output_type
+	49, // [49:49] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_message_proto_init() }
@@ -1901,7 +3972,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Reply); i {
+			switch v := v.(*Thread); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1913,7 +3984,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MessageNotif); i {
+			switch v := v.(*Reaction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1925,7 +3996,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FriendRequest); i {
+			switch v := v.(*MessageReaction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1937,7 +4008,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WSMessage); i {
+			switch v := v.(*PinsUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1949,7 +4020,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateChannel); i {
+			switch v := v.(*Reply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1961,7 +4032,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteChannel); i {
+			switch v := v.(*MessageNotif); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1973,7 +4044,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateCategory); i {
+			switch v := v.(*FriendRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1985,7 +4056,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteCategory); i {
+			switch v := v.(*WSMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1997,7 +4068,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangeStatus); i {
+			switch v := v.(*DMChannel); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2009,7 +4080,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JoinServer); i {
+			switch v := v.(*ReadState); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2021,7 +4092,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuitServer); i {
+			switch v := v.(*Ready); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2033,7 +4104,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ParticipantMove); i {
+			switch v := v.(*Server); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2045,7 +4116,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Channel); i {
+			switch v := v.(*Category); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2057,7 +4128,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangeAvatar); i {
+			switch v := v.(*UnreadState); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2069,7 +4140,7 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangeServerEl); i {
+			switch v := v.(*CreateChannel); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2081,6 +4152,126 @@ func file_message_proto_init() {
 			}
 		}
 		file_message_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteChannel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateCategory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCategory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinServer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuitServer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParticipantMove); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Channel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeAvatar); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeServerEl); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Typing); i {
 			case 0:
 				return &v.state
@@ -2092,8 +4283,128 @@ func file_message_proto_init() {
 				return nil
 			}
 		}
+		file_message_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Hello); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AckCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerSubscriptionCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TypingCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendMessageCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MarkReadCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoiceStateCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresenceCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_message_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommandAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_message_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_message_proto_msgTypes[9].OneofWrappers = []interface{}{
 		(*WSMessage_Mess)(nil),
 		(*WSMessage_CreateCategory)(nil),
 		(*WSMessage_UserId)(nil),
@@ -2111,6 +4422,24 @@ func file_message_proto_init() {
 		(*WSMessage_Typing)(nil),
 		(*WSMessage_Notification)(nil),
 		(*WSMessage_ServerPic)(nil),
+		(*WSMessage_Hello)(nil),
+		(*WSMessage_Ack)(nil),
+		(*WSMessage_VoiceState)(nil),
+		(*WSMessage_Ready)(nil),
+		(*WSMessage_ReadState)(nil),
+		(*WSMessage_DmChannel)(nil),
+		(*WSMessage_Reaction)(nil),
+		(*WSMessage_Thread)(nil),
+		(*WSMessage_PinsUpdate)(nil),
+	}
+	file_message_proto_msgTypes[29].OneofWrappers = []interface{}{
+		(*ClientCommand_Typing)(nil),
+		(*ClientCommand_SendMessage)(nil),
+		(*ClientCommand_MarkRead)(nil),
+		(*ClientCommand_VoiceState)(nil),
+		(*ClientCommand_Presence)(nil),
+		(*ClientCommand_Servers)(nil),
+		(*ClientCommand_Ack)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2118,7 +4447,7 @@ func file_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_message_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
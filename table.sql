@@ -1,10 +1,17 @@
 REMOVE TABLE IF EXISTS users;
 REMOVE TABLE IF EXISTS sessions;
+REMOVE TABLE IF EXISTS ws_tickets;
 REMOVE TABLE IF EXISTS friends;
 REMOVE TABLE IF EXISTS servers;
 REMOVE TABLE IF EXISTS channels;
 REMOVE TABLE IF EXISTS messages;
 REMOVE TABLE IF EXISTS notifications;
+REMOVE TABLE IF EXISTS read_states;
+REMOVE TABLE IF EXISTS dm_channels;
+REMOVE TABLE IF EXISTS reactions;
+REMOVE TABLE IF EXISTS emojis;
+REMOVE TABLE IF EXISTS pins;
+REMOVE TABLE IF EXISTS message_revisions;
 REMOVE TABLE IF EXISTS subscribed;
 REMOVE TABLE IF EXISTS member;
 
@@ -19,6 +26,8 @@ DEFINE FIELD avatar ON TABLE users TYPE string;
 DEFINE FIELD banner ON TABLE users TYPE string;
 DEFINE FIELD created_at ON TABLE users TYPE datetime DEFAULT time::now();
 DEFINE FIELD status ON TABLE users TYPE string;
+DEFINE FIELD preferred_status ON TABLE users TYPE option<string>;
+DEFINE FIELD last_seen ON TABLE users TYPE option<datetime>;
 DEFINE FIELD about_me ON TABLE users TYPE string;
 DEFINE INDEX idx_email ON TABLE users COLUMNS email UNIQUE;
 DEFINE INDEX idx_username ON TABLE users COLUMNS username UNIQUE;
@@ -32,6 +41,13 @@ DEFINE FIELD expires_at ON TABLE sessions TYPE datetime DEFAULT time::now() + 30
 DEFINE FIELD ip_address ON TABLE sessions TYPE string;
 DEFINE FIELD user_agent ON TABLE sessions TYPE string;
 
+-- websocket tickets
+DEFINE TABLE ws_tickets SCHEMAFULL;
+
+DEFINE FIELD session_id ON TABLE ws_tickets TYPE record<sessions>;
+DEFINE FIELD user_id ON TABLE ws_tickets TYPE record<users>;
+DEFINE FIELD expires_at ON TABLE ws_tickets TYPE datetime;
+
 -- friends relation
 DEFINE TABLE friends TYPE RELATION FROM users TO users;
 DEFINE FIELD accepted ON TABLE friends TYPE bool DEFAULT false;
@@ -46,6 +62,8 @@ DEFINE FIELD name ON TABLE servers TYPE string;
 DEFINE FIELD icon ON TABLE servers TYPE string;
 DEFINE FIELD banner ON TABLE servers TYPE string;
 DEFINE FIELD channels ON TABLE servers TYPE array<record<channels>>;
+DEFINE FIELD edit_history ON TABLE servers TYPE string DEFAULT 'members' ASSERT $value IN ['members', 'moderators'];
+DEFINE FIELD mentionable_roles ON TABLE servers TYPE array<string> DEFAULT [];
 DEFINE FIELD created_at ON TABLE channels TYPE datetime DEFAULT time::now();
 
 -- channels
@@ -56,6 +74,16 @@ DEFINE FIELD type ON TABLE channels TYPE string;
 DEFINE FIELD private ON TABLE channels TYPE bool;
 DEFINE FIELD created_at ON TABLE channels TYPE datetime DEFAULT time::now();
 
+-- threads are channels started from a message of their parent channel
+DEFINE FIELD parent_channel ON TABLE channels TYPE option<record<channels>>;
+DEFINE FIELD parent_message ON TABLE channels TYPE option<record<messages>>;
+DEFINE FIELD owner ON TABLE channels TYPE option<record<users>>;
+DEFINE FIELD message_count ON TABLE channels TYPE option<int>;
+DEFINE FIELD last_message_at ON TABLE channels TYPE option<datetime>;
+DEFINE FIELD archived ON TABLE channels TYPE option<bool>;
+DEFINE FIELD auto_archive ON TABLE channels TYPE option<int>;
+DEFINE INDEX channels_parent ON TABLE channels COLUMNS parent_channel;
+
 -- messages
 DEFINE TABLE messages SCHEMAFULL;
 
@@ -65,10 +93,89 @@ DEFINE FIELD content ON TABLE channels TYPE string;
 DEFINE FIELD edited ON TABLE channels TYPE bool;
 DEFINE FIELD updated_at ON TABLE channels TYPE datetime DEFAULT time::now();
 DEFINE FIELD created_at ON TABLE channels TYPE datetime DEFAULT time::now();
+DEFINE FIELD thread ON TABLE messages TYPE option<record<channels>>;
+DEFINE FIELD mention_roles ON TABLE messages TYPE option<array<string>>;
+DEFINE FIELD mention_channels ON TABLE messages TYPE option<array<record<channels>>>;
+DEFINE FIELD mention_everyone ON TABLE messages TYPE option<bool>;
+DEFINE FIELD mention_here ON TABLE messages TYPE option<bool>;
+DEFINE FIELD mentioned_users ON TABLE messages TYPE option<array<record<users>>>;
+DEFINE ANALYZER message_analyzer TOKENIZERS blank, class FILTERS lowercase, ascii, snowball(english);
+DEFINE INDEX messages_content ON TABLE messages FIELDS content SEARCH ANALYZER message_analyzer BM25;
 
 -- notifications
 DEFINE TABLE notifications SCHEMALESS;
 
+-- direct messages, the id of a conversation between two users is made of
+-- their sorted ids, group conversations have an owner who can remove users
+DEFINE TABLE dm_channels SCHEMAFULL;
+
+DEFINE FIELD type ON TABLE dm_channels TYPE string DEFAULT 'dm' ASSERT $value IN ['dm', 'group'];
+DEFINE FIELD name ON TABLE dm_channels TYPE option<string>;
+DEFINE FIELD icon ON TABLE dm_channels TYPE option<string>;
+DEFINE FIELD owner ON TABLE dm_channels TYPE option<record<users>>;
+DEFINE FIELD users ON TABLE dm_channels TYPE array<record<users>>;
+DEFINE FIELD last_message_id ON TABLE dm_channels TYPE option<record<messages>>;
+DEFINE FIELD last_message_at ON TABLE dm_channels TYPE datetime DEFAULT time::now();
+DEFINE FIELD created_at ON TABLE dm_channels TYPE datetime DEFAULT time::now();
+DEFINE INDEX dm_channels_users ON TABLE dm_channels COLUMNS users;
+
+-- reactions, emoji_id is set for the custom emojis of a server
+DEFINE TABLE reactions SCHEMAFULL;
+
+DEFINE FIELD message_id ON TABLE reactions TYPE record<messages>;
+DEFINE FIELD user_id ON TABLE reactions TYPE record<users>;
+DEFINE FIELD emoji ON TABLE reactions TYPE string;
+DEFINE FIELD emoji_id ON TABLE reactions TYPE string DEFAULT '';
+DEFINE FIELD created_at ON TABLE reactions TYPE datetime DEFAULT time::now();
+DEFINE INDEX unique_reactions
+        ON TABLE reactions
+        COLUMNS message_id, user_id, emoji, emoji_id UNIQUE;
+
+-- previous versions of edited messages
+DEFINE TABLE message_revisions SCHEMAFULL;
+
+DEFINE FIELD message_id ON TABLE message_revisions TYPE record<messages>;
+DEFINE FIELD content ON TABLE message_revisions TYPE string;
+DEFINE FIELD mentions ON TABLE message_revisions TYPE array<string>;
+DEFINE FIELD written_at ON TABLE message_revisions TYPE datetime;
+DEFINE FIELD created_at ON TABLE message_revisions TYPE datetime DEFAULT time::now();
+DEFINE INDEX message_revisions_message ON TABLE message_revisions COLUMNS message_id;
+
+-- pinned messages
+DEFINE TABLE pins SCHEMAFULL;
+
+DEFINE FIELD channel_id ON TABLE pins TYPE record<channels | dm_channels>;
+DEFINE FIELD message_id ON TABLE pins TYPE record<messages>;
+DEFINE FIELD pinned_by ON TABLE pins TYPE record<users>;
+DEFINE FIELD pinned_at ON TABLE pins TYPE datetime DEFAULT time::now();
+DEFINE INDEX unique_pins
+        ON TABLE pins
+        COLUMNS channel_id, message_id UNIQUE;
+
+-- custom emojis of a server, reactions refer to them by their bare id
+DEFINE TABLE emojis SCHEMAFULL;
+
+DEFINE FIELD server ON TABLE emojis TYPE record<servers>;
+DEFINE FIELD name ON TABLE emojis TYPE string;
+DEFINE FIELD image ON TABLE emojis TYPE string;
+DEFINE FIELD created_by ON TABLE emojis TYPE record<users>;
+DEFINE FIELD created_at ON TABLE emojis TYPE datetime DEFAULT time::now();
+DEFINE INDEX unique_emojis
+        ON TABLE emojis
+        COLUMNS server, name UNIQUE;
+
+-- read states
+DEFINE TABLE read_states SCHEMAFULL;
+
+DEFINE FIELD user_id ON TABLE read_states TYPE record<users>;
+DEFINE FIELD channel_id ON TABLE read_states TYPE record<channels | dm_channels>;
+DEFINE FIELD last_message_id ON TABLE read_states TYPE record<messages>;
+DEFINE FIELD last_read_at ON TABLE read_states TYPE datetime;
+DEFINE FIELD updated_at ON TABLE read_states TYPE datetime DEFAULT time::now();
+DEFINE INDEX unique_read_states
+        ON TABLE read_states
+        COLUMNS user_id, channel_id UNIQUE;
+
 -- channel subscription
 DEFINE TABLE subscribed TYPE RELATION FROM users TO channels;
 DEFINE INDEX unique_relationships